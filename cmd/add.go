@@ -11,7 +11,6 @@ import (
 	"github.com/louiss0/go-toolkit/internal/modindex/config"
 	"github.com/louiss0/go-toolkit/internal/prompt"
 	"github.com/louiss0/go-toolkit/internal/runner"
-	"github.com/louiss0/go-toolkit/validation"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
@@ -21,12 +20,15 @@ func NewAddCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configPa
 	userFlag := custom_flags.NewEmptyStringFlag("user")
 	var allowFull bool
 	var dryRun bool
+	var verify bool
+	var jsonOutput bool
 	var presetFlags []string
 	var packageFlags []string
 
 	cmd := &cobra.Command{
 		Use:   "add [package] [packages...]",
 		Short: "Add Go module dependencies",
+		Long:  "Add Go module dependencies.\n\n" + modulePathHelp,
 		Args: func(cmd *cobra.Command, args []string) error {
 			containsNoneTag := lo.ContainsBy(args, func(input string) bool {
 				return strings.Contains(input, "@none")
@@ -42,19 +44,18 @@ func NewAddCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configPa
 			if err != nil {
 				return err
 			}
-			promptPackages := []string(nil)
+			selection := packageSelection{}
 			if len(args) == 0 && len(packageFlags) == 0 && len(presetFlags) == 0 {
-				inputs, err := promptAddPackages(cmd, promptRunner)
+				selection, err = promptAddPackages(cmd, promptRunner, values)
 				if err != nil {
 					if errors.Is(err, huh.ErrUserAborted) {
 						return nil
 					}
-					return err
+					return cmdutil.MissingInput(err, "missing packages; pass them as arguments or use --package or --preset")
 				}
-				promptPackages = inputs
 			}
 
-			installPackages, err := resolveInstallPackages(values, packageFlags, presetFlags, promptPackages)
+			installPackages, err := resolveInstallPackages(values, packageFlags, append(presetFlags, selection.Presets...), selection.Packages)
 			if err != nil {
 				return err
 			}
@@ -64,47 +65,14 @@ func NewAddCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configPa
 				return custom_errors.CreateInvalidInputErrorWithMessage("at least one package or preset is required")
 			}
 
-			site := config.ResolveSite(siteFlag.String(), values)
-			user, err := config.ResolveUser(userFlag.String(), values, site)
-			if err != nil {
-				if errors.Is(err, config.ErrMissingUser) {
-					return custom_errors.CreateInvalidInputErrorWithMessage("missing user; run go-toolkit config set-user <user>")
-				}
-				return err
-			}
-
-			allowCustomSite := allowFull || (siteFlag.String() == "" && values.Site != "")
-			if err := cmdutil.ValidateSite(site, allowCustomSite); err != nil {
-				return err
-			}
-
-			targetPackages, err = assurePackageProviders(cmd, promptRunner, values, site, targetPackages)
-			if err != nil {
-				if errors.Is(err, huh.ErrUserAborted) {
-					return nil
-				}
-				return err
-			}
-
-			cmdutil.LogInfoIfProduction("add: resolving module paths for %s", site)
-			uniqueModules, err := resolveModulePaths(targetPackages, site, user)
-			if err != nil {
-				return err
-			}
-			if dryRun {
-				cmdutil.LogInfoIfProduction("add: dry run output")
-				return cmdutil.WriteLine(
-					cmd.OutOrStdout(),
-					"go "+strings.Join(append([]string{"get"}, uniqueModules...), " "),
-				)
-			}
-
-			cmdutil.LogInfoIfProduction("add: executing go get")
-			if err := commandRunner.Run(cmd, "go", append([]string{"get"}, uniqueModules...)...); err != nil {
-				return err
-			}
-
-			return nil
+			return addPackages(cmd, commandRunner, promptRunner, values, targetPackages, addOptions{
+				site:       siteFlag.String(),
+				user:       userFlag.String(),
+				allowFull:  allowFull,
+				dryRun:     dryRun,
+				verify:     verify,
+				jsonOutput: jsonOutput,
+			})
 		},
 	}
 
@@ -112,6 +80,8 @@ func NewAddCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configPa
 	cmd.Flags().Var(&siteFlag, "site", "override the configured site")
 	cmd.Flags().BoolVar(&allowFull, "full", false, "allow a custom module site")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the go command without running it")
+	cmd.Flags().BoolVar(&verify, "verify", false, "check that each module exists on the module proxy first")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print requirement changes as JSON")
 	cmd.Flags().StringSliceVar(&packageFlags, "package", nil, "package preset entries or module paths to add")
 	cmd.Flags().StringSliceVar(&presetFlags, "preset", nil, "package preset names to add")
 	cmdutil.RegisterSiteCompletion(cmd, "site")
@@ -119,19 +89,66 @@ func NewAddCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configPa
 	return cmd
 }
 
-func promptAddPackages(cmd *cobra.Command, runner prompt.Runner) ([]string, error) {
-	packageInput, err := runner.Input(cmd, prompt.Input{
-		Title:       "Packages to add",
-		Description: "Use space-separated username/package or username/package/vN entries; presets can be used with --preset.",
-		Placeholder: "samber/lo onsi/ginkgo/v2",
-		Validate: func(value string) error {
-			_, err := validation.RequiredShortPackageList(value, "packages to add")
-			return err
-		},
+type addOptions struct {
+	site       string
+	user       string
+	allowFull  bool
+	dryRun     bool
+	verify     bool
+	jsonOutput bool
+}
+
+func addPackages(cmd *cobra.Command, commandRunner runner.Runner, promptRunner prompt.Runner, values config.Values, targetPackages []string, options addOptions) error {
+	site := config.ResolveSite(options.site, values)
+	user := options.user
+	needsUser := lo.ContainsBy(targetPackages, func(packageName string) bool {
+		return !strings.Contains(packageName, "/")
 	})
+	if needsUser {
+		resolvedUser, err := config.ResolveUser(options.user, values, site)
+		if err != nil {
+			if errors.Is(err, config.ErrMissingUser) {
+				return custom_errors.CreateInvalidInputErrorWithMessage("missing user; run go-toolkit config set-user <user>")
+			}
+			return err
+		}
+		user = resolvedUser
+	}
+
+	allowCustomSite := options.allowFull || (options.site == "" && values.Site != "")
+	if err := cmdutil.ValidateSite(site, allowCustomSite); err != nil {
+		return err
+	}
+
+	targetPackages, err := assurePackageProviders(cmd, promptRunner, values, site, targetPackages)
+	if err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return nil
+		}
+		return err
+	}
+
+	cmdutil.LogInfoIfProduction("add: resolving module paths for %s", site)
+	uniqueModules, err := resolveModulePaths(targetPackages, site, user)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	if options.verify {
+		if err := verifyModulePaths(cmd, values, uniqueModules); err != nil {
+			return err
+		}
+	}
+	if options.dryRun {
+		cmdutil.LogInfoIfProduction("add: dry run output")
+		return cmdutil.WriteLine(
+			cmd.OutOrStdout(),
+			"go "+strings.Join(append([]string{"get"}, uniqueModules...), " "),
+		)
+	}
+
+	return runGoGetWithDiff(cmd, commandRunner, "add", uniqueModules, options.jsonOutput)
+}
 
-	return validation.RequiredShortPackageList(packageInput, "packages to add")
+func promptAddPackages(cmd *cobra.Command, runner prompt.Runner, values config.Values) (packageSelection, error) {
+	return promptPackageSelection(cmd, runner, values, "Packages to add", "packages to add")
 }
@@ -1,6 +1,7 @@
 package cmd_test
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 
@@ -67,6 +68,68 @@ var Add = Describe("add command", func() {
 		runner.AssertExpectations(GinkgoT())
 	})
 
+	It("adds full module paths without a configured user", func() {
+		runner := &testhelpers.RunnerMock{}
+		configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+		err := os.WriteFile(configPath, []byte("site = \"github.com\"\n"), 0o644)
+		assert.NoError(err)
+
+		runner.On("Run", mock.Anything, "go", []string{"get", "github.com/onsi/ginkgo/v2", "golang.org/x/mod"}).Return(nil).Once()
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "add", "github.com/onsi/ginkgo/v2", "golang.org/x/mod")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+	})
+
+	It("requires a configured user for packages without a scope", func() {
+		runner := &testhelpers.RunnerMock{}
+		configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+		err := os.WriteFile(configPath, []byte("site = \"gitlab.com\"\n"), 0o644)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "add", "samber/lo", "toolkit")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "missing user; run go-toolkit config set-user <user>")
+		runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("keeps two element paths on a dotted host as full module paths", func() {
+		runner := &testhelpers.RunnerMock{}
+		configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+		err := writeDefaultConfig(configPath)
+		assert.NoError(err)
+
+		runner.On("Run", mock.Anything, "go", []string{"get", "go.uber.org/zap", "github.com/samber/lo"}).Return(nil).Once()
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "add", "go.uber.org/zap", "samber/lo")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+	})
+
 	It("adds a short package path with a major version suffix", func() {
 		runner := &testhelpers.RunnerMock{}
 		tempDir := GinkgoT().TempDir()
@@ -152,7 +215,7 @@ var Add = Describe("add command", func() {
 		assert.NoError(err)
 
 		promptRunner := testhelpers.NewPromptRunnerMock(
-			testhelpers.PromptStep{Kind: testhelpers.PromptStepInput, Value: "samber/lo stretchr/testify"},
+			testhelpers.PromptStep{Kind: testhelpers.PromptStepText, Value: "samber/lo\nstretchr/testify"},
 		)
 
 		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
@@ -174,6 +237,26 @@ var Add = Describe("add command", func() {
 		runner.AssertExpectations(GinkgoT())
 	})
 
+	It("names the missing flags instead of prompting with --no-input", func() {
+		runner := &testhelpers.RunnerMock{}
+		configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+		err := writeDefaultConfig(configPath)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "add", "--no-input")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "missing packages; pass them as arguments or use --package or --preset")
+		runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	It("rejects invalid package prompt syntax", func() {
 		runner := &testhelpers.RunnerMock{}
 		tempDir := GinkgoT().TempDir()
@@ -183,7 +266,7 @@ var Add = Describe("add command", func() {
 		assert.NoError(err)
 
 		promptRunner := testhelpers.NewPromptRunnerMock(
-			testhelpers.PromptStep{Kind: testhelpers.PromptStepInput, Value: "samber/lo, stretchr/testify"},
+			testhelpers.PromptStep{Kind: testhelpers.PromptStepText, Value: "samber/lo, stretchr/testify"},
 		)
 
 		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
@@ -225,6 +308,36 @@ var Add = Describe("add command", func() {
 		runner.AssertExpectations(GinkgoT())
 	})
 
+	It("adds presets chosen in the package prompt", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\n[package_presets]\ncli = [\"samber/lo\"]\ntest = [\"stretchr/testify\"]\n"), 0o644)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner: runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(
+				testhelpers.PromptStep{Kind: testhelpers.PromptStepMultiSelect, Values: []string{"cli"}},
+				testhelpers.PromptStep{Kind: testhelpers.PromptStepText, Value: "spf13/cobra"},
+			),
+			ConfigPath: configPath,
+		})
+
+		runner.On(
+			"Run",
+			mock.Anything,
+			"go",
+			[]string{"get", "github.com/samber/lo", "github.com/spf13/cobra"},
+		).Return(nil).Once()
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "add")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+	})
+
 	It("assures default providers for short package paths when enabled", func() {
 		runner := &testhelpers.RunnerMock{}
 		tempDir := GinkgoT().TempDir()
@@ -277,4 +390,158 @@ var Add = Describe("add command", func() {
 		assert.NoError(err)
 		runner.AssertExpectations(GinkgoT())
 	})
+
+	It("prints the requirement changes made to go.mod", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+		modFilePath := filepath.Join(tempDir, "go.mod")
+		workingDir, err := os.Getwd()
+		assert.NoError(err)
+
+		err = writeDefaultConfig(configPath)
+		assert.NoError(err)
+		err = os.WriteFile(modFilePath, []byte("module github.com/lou/toolkit\n\ngo 1.24\n\nrequire github.com/samber/lo v1.49.1\n"), 0o644)
+		assert.NoError(err)
+
+		err = os.Chdir(tempDir)
+		assert.NoError(err)
+		DeferCleanup(func() {
+			_ = os.Chdir(workingDir)
+		})
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		runner.On("Run", mock.Anything, "go", []string{"get", "github.com/samber/lo", "github.com/spf13/cobra"}).
+			Run(func(mock.Arguments) {
+				_ = os.WriteFile(modFilePath, []byte("module github.com/lou/toolkit\n\ngo 1.24\n\nrequire (\n\tgithub.com/samber/lo v1.52.0\n\tgithub.com/spf13/cobra v1.10.2\n)\n"), 0o644)
+			}).
+			Return(nil).Once()
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "add", "samber/lo", "spf13/cobra")
+
+		assert.NoError(err)
+		assert.Equal("added github.com/spf13/cobra v1.10.2\nupgraded github.com/samber/lo v1.49.1 => v1.52.0\n", output)
+	})
+
+	It("prints empty requirement changes as JSON outside a module", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+		workingDir, err := os.Getwd()
+		assert.NoError(err)
+
+		err = writeDefaultConfig(configPath)
+		assert.NoError(err)
+
+		err = os.Chdir(tempDir)
+		assert.NoError(err)
+		DeferCleanup(func() {
+			_ = os.Chdir(workingDir)
+		})
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		runner.On("Run", mock.Anything, "go", []string{"get", "github.com/samber/lo"}).Return(nil).Once()
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "add", "samber/lo", "--json")
+
+		assert.NoError(err)
+		var changes map[string][]map[string]any
+		assert.NoError(json.Unmarshal([]byte(output), &changes))
+		assert.Equal(map[string][]map[string]any{
+			"added":      {},
+			"upgraded":   {},
+			"downgraded": {},
+			"promoted":   {},
+			"removed":    {},
+		}, changes)
+	})
+
+	Describe("with --verify", func() {
+		var runner *testhelpers.RunnerMock
+		var configPath string
+
+		BeforeEach(func() {
+			runner = &testhelpers.RunnerMock{}
+			tempDir := GinkgoT().TempDir()
+			GinkgoT().Setenv("XDG_CACHE_HOME", GinkgoT().TempDir())
+			configPath = filepath.Join(tempDir, "config.toml")
+			err := writeDefaultConfig(configPath)
+			assert.NoError(err)
+
+			server := testhelpers.NewFakeProxy(map[string][]string{
+				"github.com/samber/lo":         {"v1.52.0"},
+				"github.com/go-resty/resty/v2": {"v2.17.1"},
+			})
+			DeferCleanup(server.Close)
+
+			previous, hadPrevious := os.LookupEnv("GOPROXY")
+			err = os.Setenv("GOPROXY", server.URL)
+			assert.NoError(err)
+			DeferCleanup(func() {
+				if hadPrevious {
+					_ = os.Setenv("GOPROXY", previous)
+					return
+				}
+				_ = os.Unsetenv("GOPROXY")
+			})
+		})
+
+		It("adds modules that exist on the proxy", func() {
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			output, err := testhelpers.ExecuteCmd(rootCmd, "add", "samber/lo", "--verify", "--dry-run")
+
+			assert.NoError(err)
+			assert.Contains(output, "go get github.com/samber/lo")
+		})
+
+		It("checks the proxy from the search config", func() {
+			server := testhelpers.NewFakeProxy(map[string][]string{
+				"github.com/acme/internal-tool": {"v0.3.0"},
+			})
+			DeferCleanup(server.Close)
+			err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\n[search]\nproxy = \""+server.URL+"\"\n"), 0o644)
+			assert.NoError(err)
+
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			output, err := testhelpers.ExecuteCmd(rootCmd, "add", "acme/internal-tool", "--verify", "--dry-run")
+
+			assert.NoError(err)
+			assert.Contains(output, "go get github.com/acme/internal-tool")
+		})
+
+		It("rejects missing modules with suggestions", func() {
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			_, err := testhelpers.ExecuteCmd(rootCmd, "add", "go-resty/resty", "--verify")
+
+			assert.Error(err)
+			assert.Contains(err.Error(), "module github.com/go-resty/resty was not found")
+			assert.Contains(err.Error(), "did you mean github.com/go-resty/resty/v2?")
+			runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything)
+		})
+	})
 })
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/louiss0/go-toolkit/internal/cache"
+	"github.com/louiss0/go-toolkit/internal/cmdutil"
+	"github.com/louiss0/go-toolkit/internal/modindex"
+	"github.com/louiss0/go-toolkit/internal/modindex/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCacheCmd(configPath *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect and manage cached module proxy responses",
+	}
+
+	cmd.AddCommand(newCacheInfoCmd(configPath))
+	cmd.AddCommand(newCachePruneCmd(configPath))
+	cmd.AddCommand(newCacheClearCmd(configPath))
+
+	return cmd
+}
+
+func newCacheInfoCmd(configPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "info",
+		Short: "Show the cache location, size and expired entries",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			values, err := config.Load(*configPath)
+			if err != nil {
+				return err
+			}
+
+			store, err := openProxyCache(values)
+			if err != nil {
+				return err
+			}
+
+			cmdutil.LogInfoIfProduction("cache info: reading %s", store.Dir)
+			stats, err := store.Stats()
+			if err != nil {
+				return err
+			}
+
+			lines := []string{
+				"proxy cache: " + store.Dir,
+				fmt.Sprintf("entries: %d (%d expired)", stats.Entries, stats.Expired),
+				fmt.Sprintf("size: %d bytes", stats.Bytes),
+				"ttl: " + store.TTL.String(),
+			}
+
+			indexPath, err := modindex.DefaultCachePath()
+			if err != nil {
+				return err
+			}
+			index, err := modindex.LoadIndex(indexPath)
+			if err != nil {
+				return err
+			}
+			lines = append(lines, fmt.Sprintf("module index: %s (%d modules)", indexPath, len(index.Modules)))
+
+			return cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(lines, "\n"))
+		},
+	}
+}
+
+func newCachePruneCmd(configPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "prune",
+		Short: "Remove cached proxy responses older than the cache TTL",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			values, err := config.Load(*configPath)
+			if err != nil {
+				return err
+			}
+
+			store, err := openProxyCache(values)
+			if err != nil {
+				return err
+			}
+
+			cmdutil.LogInfoIfProduction("cache prune: removing entries older than %s", store.TTL)
+			removed, err := store.Prune()
+			if err != nil {
+				return err
+			}
+
+			return cmdutil.WriteLine(cmd.OutOrStdout(), fmt.Sprintf("removed %d expired entries", removed))
+		},
+	}
+}
+
+func newCacheClearCmd(configPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove every cached proxy response and the module index",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			values, err := config.Load(*configPath)
+			if err != nil {
+				return err
+			}
+
+			store, err := openProxyCache(values)
+			if err != nil {
+				return err
+			}
+
+			cmdutil.LogInfoIfProduction("cache clear: removing %s", store.Dir)
+			if err := store.Clear(); err != nil {
+				return err
+			}
+
+			indexPath, err := modindex.DefaultCachePath()
+			if err != nil {
+				return err
+			}
+			if err := os.Remove(indexPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+
+			return cmdutil.WriteLine(cmd.OutOrStdout(), "cache cleared")
+		},
+	}
+}
+
+func openProxyCache(values config.Values) (*cache.Store, error) {
+	cacheDir, err := cache.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.NewStore(filepath.Join(cacheDir, "proxy"), config.ResolveCacheTTL(values)), nil
+}
@@ -0,0 +1,68 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/louiss0/go-toolkit/cmd"
+	"github.com/louiss0/go-toolkit/internal/testhelpers"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var Cache = Describe("cache command", func() {
+	assert := assert.New(GinkgoT())
+
+	var cacheHome string
+	var configPath string
+
+	BeforeEach(func() {
+		cacheHome = GinkgoT().TempDir()
+		GinkgoT().Setenv("XDG_CACHE_HOME", cacheHome)
+		configPath = filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+		server := testhelpers.NewFakeProxy(map[string][]string{
+			"github.com/acme/tool": {"v1.0.0"},
+		})
+		DeferCleanup(server.Close)
+
+		err := os.WriteFile(configPath, []byte("[search]\nproxy = \""+server.URL+"\"\ncache_ttl = \"1h\"\n"), 0o644)
+		assert.NoError(err)
+
+		_, err = testhelpers.ExecuteCmd(newCacheRootCmd(configPath), "search", "acme/tool")
+		assert.NoError(err)
+	})
+
+	It("reports cached entries", func() {
+		output, err := testhelpers.ExecuteCmd(newCacheRootCmd(configPath), "cache", "info")
+
+		assert.NoError(err)
+		assert.Contains(output, "proxy cache: "+filepath.Join(cacheHome, "go-toolkit", "proxy"))
+		assert.Contains(output, "entries: 1 (0 expired)")
+		assert.Contains(output, "ttl: 1h0m0s")
+	})
+
+	It("keeps fresh entries when pruning", func() {
+		output, err := testhelpers.ExecuteCmd(newCacheRootCmd(configPath), "cache", "prune")
+
+		assert.NoError(err)
+		assert.Equal("removed 0 expired entries\n", output)
+	})
+
+	It("clears the cache", func() {
+		output, err := testhelpers.ExecuteCmd(newCacheRootCmd(configPath), "cache", "clear")
+
+		assert.NoError(err)
+		assert.Equal("cache cleared\n", output)
+		assert.NoDirExists(filepath.Join(cacheHome, "go-toolkit", "proxy"))
+	})
+})
+
+func newCacheRootCmd(configPath string) *cobra.Command {
+	return cmd.NewRootCmdWithOptions(cmd.RootOptions{
+		Runner:       &testhelpers.RunnerMock{},
+		PromptRunner: testhelpers.NewPromptRunnerMock(),
+		ConfigPath:   configPath,
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/louiss0/go-toolkit/custom_flags"
 	"github.com/louiss0/go-toolkit/internal/cmdutil"
 	"github.com/louiss0/go-toolkit/internal/modindex/config"
+	"github.com/louiss0/go-toolkit/internal/project"
 	"github.com/louiss0/go-toolkit/internal/prompt"
 	"github.com/louiss0/go-toolkit/internal/runner"
 	"github.com/louiss0/go-toolkit/validation"
@@ -35,9 +36,12 @@ func NewConfigCmd(commandRunner runner.Runner, configPath *string, promptRunner
 	cmd.AddCommand(newConfigSetAssureProvidersCmd(configPath))
 	cmd.AddCommand(newConfigSetScaffoldTestsCmd(configPath))
 	cmd.AddCommand(newConfigSetScaffoldGitCmd(configPath))
+	cmd.AddCommand(newConfigSetSearchProxyCmd(configPath))
+	cmd.AddCommand(newConfigSetCacheTTLCmd(configPath))
 	cmd.AddCommand(newConfigProviderCmd(configPath))
-	cmd.AddCommand(newConfigPackagePresetCmd(configPath))
+	cmd.AddCommand(newConfigPackagePresetCmd(configPath, promptRunner))
 	cmd.AddCommand(newConfigGlobalPackageCmd(configPath))
+	cmd.AddCommand(newConfigTemplateCmd(configPath))
 	cmd.AddCommand(newConfigRemoveCmd(configPath))
 
 	return cmd
@@ -233,7 +237,7 @@ func newConfigInitCmd(configPath *string, promptRunner prompt.Runner) *cobra.Com
 					if errors.Is(err, huh.ErrUserAborted) {
 						return nil
 					}
-					return err
+					return cmdutil.MissingInput(err, "missing --user; pass --user and optionally --site")
 				}
 				promptValues = inputs
 			}
@@ -294,7 +298,9 @@ type configSummary struct {
 	Scaffold        config.ScaffoldConfig   `json:"scaffold"`
 	Providers       []config.ProviderConfig `json:"providers"`
 	PackagePresets  map[string][]string     `json:"package_presets"`
-	GlobalPackages  []string                `json:"global_packages"`
+	GlobalPackages  []config.GlobalPackage  `json:"global_packages"`
+	Templates       map[string]string       `json:"templates"`
+	Search          config.SearchConfig     `json:"search"`
 }
 
 func promptConfigInitInputs(cmd *cobra.Command, runner prompt.Runner) (configInitPrompt, error) {
@@ -355,7 +361,7 @@ func promptConfigInitInputs(cmd *cobra.Command, runner prompt.Runner) (configIni
 	return promptValues, nil
 }
 
-func buildProviderOptions() []prompt.Option {
+func buildSiteOptions() []prompt.Option {
 	knownSites := config.KnownSites()
 	options := lo.Map(knownSites, func(site string, _ int) prompt.Option {
 		label := site
@@ -365,12 +371,14 @@ func buildProviderOptions() []prompt.Option {
 		return prompt.Option{Label: label, Value: site}
 	})
 
-	options = append(options,
-		prompt.Option{Label: "Custom", Value: providerCustom},
+	return append(options, prompt.Option{Label: "Custom", Value: providerCustom})
+}
+
+func buildProviderOptions() []prompt.Option {
+	return append(buildSiteOptions(),
 		prompt.Option{Label: "Skip", Value: providerSkip},
 		prompt.Option{Label: "Skip remaining", Value: providerSkipRemaining},
 	)
-	return options
 }
 
 func buildConfigSummary(configPath string, values config.Values) (configSummary, error) {
@@ -390,7 +398,11 @@ func buildConfigSummary(configPath string, values config.Values) (configSummary,
 	}
 	globalPackages := values.GlobalPackages
 	if globalPackages == nil {
-		globalPackages = []string{}
+		globalPackages = []config.GlobalPackage{}
+	}
+	templates := values.Templates
+	if templates == nil {
+		templates = map[string]string{}
 	}
 
 	return configSummary{
@@ -402,6 +414,8 @@ func buildConfigSummary(configPath string, values config.Values) (configSummary,
 		Providers:       providers,
 		PackagePresets:  packagePresets,
 		GlobalPackages:  globalPackages,
+		Templates:       templates,
+		Search:          values.Search,
 	}, nil
 }
 
@@ -487,6 +501,50 @@ func newConfigSetScaffoldGitCmd(configPath *string) *cobra.Command {
 	}
 }
 
+func newConfigSetSearchProxyCmd(configPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-search-proxy <proxy list>",
+		Short: "Override GOPROXY for search; pass an empty value to use the Go environment",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdutil.LogInfoIfProduction("config set-search-proxy: loading config")
+			values, err := config.Load(*configPath)
+			if err != nil {
+				return err
+			}
+
+			values.Search.Proxy = strings.TrimSpace(args[0])
+			if err := config.Save(*configPath, values); err != nil {
+				return err
+			}
+
+			return cmdutil.WriteLine(cmd.OutOrStdout(), "search proxy saved")
+		},
+	}
+}
+
+func newConfigSetCacheTTLCmd(configPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-cache-ttl <duration>",
+		Short: "Set how long cached proxy responses stay fresh, such as 12h",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdutil.LogInfoIfProduction("config set-cache-ttl: loading config")
+			values, err := config.Load(*configPath)
+			if err != nil {
+				return err
+			}
+
+			values.Search.CacheTTL = strings.TrimSpace(args[0])
+			if err := config.Save(*configPath, values); err != nil {
+				return err
+			}
+
+			return cmdutil.WriteLine(cmd.OutOrStdout(), "cache ttl saved")
+		},
+	}
+}
+
 func newConfigProviderCmd(configPath *string) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "provider",
@@ -500,13 +558,13 @@ func newConfigProviderCmd(configPath *string) *cobra.Command {
 	return cmd
 }
 
-func newConfigPackagePresetCmd(configPath *string) *cobra.Command {
+func newConfigPackagePresetCmd(configPath *string, promptRunner prompt.Runner) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "package-preset",
 		Short: "Manage package install presets",
 	}
 
-	cmd.AddCommand(newConfigPackagePresetAddCmd(configPath))
+	cmd.AddCommand(newConfigPackagePresetAddCmd(configPath, promptRunner))
 	cmd.AddCommand(newConfigPackagePresetListCmd(configPath))
 	cmd.AddCommand(newConfigPackagePresetRemoveCmd(configPath))
 
@@ -619,7 +677,7 @@ func newConfigProviderListCmd(configPath *string) *cobra.Command {
 	}
 }
 
-func newConfigPackagePresetAddCmd(configPath *string) *cobra.Command {
+func newConfigPackagePresetAddCmd(configPath *string, promptRunner prompt.Runner) *cobra.Command {
 	nameFlag := custom_flags.NewEmptyStringFlag("name")
 	var packageFlags []string
 
@@ -631,9 +689,6 @@ func newConfigPackagePresetAddCmd(configPath *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if len(packageFlags) == 0 {
-				return custom_errors.CreateInvalidInputErrorWithMessage("at least one package is required")
-			}
 			trimmedPackages, err := validation.NonEmptyStrings(packageFlags, "package values")
 			if err != nil {
 				return err
@@ -647,6 +702,17 @@ func newConfigPackagePresetAddCmd(configPath *string) *cobra.Command {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(packageFlags) == 0 {
+				packages, err := promptPresetPackages(cmd, promptRunner)
+				if err != nil {
+					if errors.Is(err, huh.ErrUserAborted) {
+						return nil
+					}
+					return cmdutil.MissingInput(err, "at least one package is required")
+				}
+				packageFlags = packages
+			}
+
 			cmdutil.LogInfoIfProduction("config package preset add: loading config")
 			values, err := config.Load(*configPath)
 			if err != nil {
@@ -671,6 +737,25 @@ func newConfigPackagePresetAddCmd(configPath *string) *cobra.Command {
 	return cmd
 }
 
+func promptPresetPackages(cmd *cobra.Command, runner prompt.Runner) ([]string, error) {
+	packageInput, err := runner.Text(cmd, prompt.Text{
+		Title:       "Preset packages",
+		Description: "One package entry or module path per line.",
+		Placeholder: "samber/lo\ngithub.com/spf13/cobra",
+		Validate: func(value string) error {
+			if len(strings.Fields(value)) == 0 {
+				return custom_errors.CreateInvalidInputErrorWithMessage("at least one package is required")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(packageInput), nil
+}
+
 func newConfigPackagePresetRemoveCmd(configPath *string) *cobra.Command {
 	nameFlag := custom_flags.NewEmptyStringFlag("name")
 
@@ -765,10 +850,10 @@ func newConfigGlobalPackageAddCmd(configPath *string) *cobra.Command {
 				return err
 			}
 			if lo.ContainsBy(trimmedPackages, func(pkg string) bool {
-				return !validation.IsFullModulePath(pkg)
+				return !validation.IsFullModulePath(config.ParseGlobalPackage(pkg).Path)
 			}) {
 				return custom_errors.CreateInvalidInputErrorWithMessage(
-					"package values must be full module paths (for example: github.com/user/module)",
+					"package values must be full module paths with an optional @version (for example: github.com/user/module@v1.2.3)",
 				)
 			}
 			packageFlags = trimmedPackages
@@ -782,7 +867,12 @@ func newConfigGlobalPackageAddCmd(configPath *string) *cobra.Command {
 				return err
 			}
 
-			values.GlobalPackages = lo.Uniq(append(values.GlobalPackages, packageFlags...))
+			values.GlobalPackages = config.UpsertGlobalPackages(
+				values.GlobalPackages,
+				lo.Map(packageFlags, func(pkg string, _ int) config.GlobalPackage {
+					return config.ParseGlobalPackage(pkg)
+				})...,
+			)
 			if err := config.Save(*configPath, values); err != nil {
 				return err
 			}
@@ -791,7 +881,7 @@ func newConfigGlobalPackageAddCmd(configPath *string) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringSliceVar(&packageFlags, "package", nil, "full module paths to add")
+	cmd.Flags().StringSliceVar(&packageFlags, "package", nil, "full module paths to add, optionally pinned with @version or @constraint")
 
 	return cmd
 }
@@ -821,13 +911,9 @@ func newConfigGlobalPackageRemoveCmd(configPath *string) *cobra.Command {
 				return err
 			}
 
-			removeSet := lo.SliceToMap(packageFlags, func(pkg string) (string, struct{}) {
-				return pkg, struct{}{}
-			})
-			filtered := lo.Filter(values.GlobalPackages, func(pkg string, _ int) bool {
-				_, found := removeSet[pkg]
-				return !found
-			})
+			filtered := config.RemoveGlobalPackages(values.GlobalPackages, lo.Map(packageFlags, func(pkg string, _ int) string {
+				return config.ParseGlobalPackage(pkg).Path
+			}))
 
 			if len(filtered) == len(values.GlobalPackages) {
 				return custom_errors.CreateInvalidInputErrorWithMessage("no matching global packages found")
@@ -859,7 +945,127 @@ func newConfigGlobalPackageListCmd(configPath *string) *cobra.Command {
 			}
 
 			if len(values.GlobalPackages) > 0 {
-				return cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(values.GlobalPackages, "\n"))
+				return cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(lo.Map(values.GlobalPackages, func(pkg config.GlobalPackage, _ int) string {
+					return pkg.String()
+				}), "\n"))
+			}
+
+			return nil
+		},
+	}
+}
+
+func newConfigTemplateCmd(configPath *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Manage user-defined project templates",
+	}
+
+	cmd.AddCommand(newConfigTemplateAddCmd(configPath))
+	cmd.AddCommand(newConfigTemplateListCmd(configPath))
+	cmd.AddCommand(newConfigTemplateRemoveCmd(configPath))
+
+	return cmd
+}
+
+func newConfigTemplateAddCmd(configPath *string) *cobra.Command {
+	nameFlag := custom_flags.NewEmptyStringFlag("name")
+	pathFlag := custom_flags.NewEmptyStringFlag("path")
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Register a template directory",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, err := validation.RequiredString(nameFlag.String(), "template name")
+			if err != nil {
+				return err
+			}
+			path, err := validation.RequiredString(pathFlag.String(), "template path")
+			if err != nil {
+				return err
+			}
+			if project.IsBuiltinTemplate(name) {
+				return custom_errors.CreateInvalidInputErrorWithMessage(
+					fmt.Sprintf("template name %s is reserved for a built-in template", name),
+				)
+			}
+
+			cmdutil.LogInfoIfProduction("config template add: loading config")
+			values, err := config.Load(*configPath)
+			if err != nil {
+				return err
+			}
+
+			if values.Templates == nil {
+				values.Templates = map[string]string{}
+			}
+			values.Templates[name] = path
+			if err := config.Save(*configPath, values); err != nil {
+				return err
+			}
+
+			return cmdutil.WriteLine(cmd.OutOrStdout(), "template saved")
+		},
+	}
+
+	cmd.Flags().Var(&nameFlag, "name", "template name")
+	cmd.Flags().Var(&pathFlag, "path", "template directory")
+
+	return cmd
+}
+
+func newConfigTemplateRemoveCmd(configPath *string) *cobra.Command {
+	nameFlag := custom_flags.NewEmptyStringFlag("name")
+
+	cmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove a registered template",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, err := validation.RequiredString(nameFlag.String(), "template name")
+			if err != nil {
+				return err
+			}
+
+			cmdutil.LogInfoIfProduction("config template remove: loading config")
+			values, err := config.Load(*configPath)
+			if err != nil {
+				return err
+			}
+
+			if _, ok := values.Templates[name]; !ok {
+				return custom_errors.CreateInvalidInputErrorWithMessage("template name not found")
+			}
+
+			delete(values.Templates, name)
+			if err := config.Save(*configPath, values); err != nil {
+				return err
+			}
+
+			return cmdutil.WriteLine(cmd.OutOrStdout(), "template removed")
+		},
+	}
+
+	cmd.Flags().Var(&nameFlag, "name", "template name")
+
+	return cmd
+}
+
+func newConfigTemplateListCmd(configPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List registered templates",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdutil.LogInfoIfProduction("config template list: loading config")
+			values, err := config.Load(*configPath)
+			if err != nil {
+				return err
+			}
+
+			rows := lo.Map(config.KnownTemplateNames(values), func(name string, _ int) string {
+				return fmt.Sprintf("%s\t%s", name, values.Templates[name])
+			})
+			if len(rows) > 0 {
+				return cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(rows, "\n"))
 			}
 
 			return nil
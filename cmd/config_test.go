@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/louiss0/go-toolkit/cmd"
 	"github.com/louiss0/go-toolkit/internal/modindex/config"
+	"github.com/louiss0/go-toolkit/internal/prompt"
 	"github.com/louiss0/go-toolkit/internal/testhelpers"
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/stretchr/testify/assert"
@@ -271,6 +273,45 @@ var Config = Describe("config command", func() {
 		assert.Contains(output, "github.com/spf13/cobra")
 	})
 
+	It("prompts for package preset entries when none are passed", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner: runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(
+				testhelpers.PromptStep{Kind: testhelpers.PromptStepText, Value: "samber/lo\ngithub.com/spf13/cobra\n"},
+			),
+			ConfigPath: configPath,
+		})
+
+		_, err := testhelpers.ExecuteCmd(rootCmd, "config", "package-preset", "add", "--name", "cli")
+		assert.NoError(err)
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Equal([]string{"samber/lo", "github.com/spf13/cobra"}, values.PackagePresets["cli"])
+	})
+
+	It("requires packages when package preset prompts are disabled", func() {
+		configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner: &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(
+				testhelpers.PromptStep{Kind: testhelpers.PromptStepText, Err: prompt.ErrPromptsDisabled},
+			),
+			ConfigPath: configPath,
+		})
+
+		_, err := testhelpers.ExecuteCmd(rootCmd, "config", "package-preset", "add", "--name", "cli")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "at least one package is required")
+		assert.NoFileExists(configPath)
+	})
+
 	It("rejects empty package preset keys during flag validation", func() {
 		runner := &testhelpers.RunnerMock{}
 		tempDir := GinkgoT().TempDir()
@@ -336,6 +377,59 @@ var Config = Describe("config command", func() {
 		assert.False(config.ResolveInitGit(values))
 	})
 
+	It("updates the search proxy override", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err := testhelpers.ExecuteCmd(rootCmd, "config", "set-search-proxy", "https://athens.corp,direct")
+		assert.NoError(err)
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Equal("https://athens.corp,direct", values.Search.Proxy)
+	})
+
+	It("updates the cache ttl", func() {
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err := testhelpers.ExecuteCmd(rootCmd, "config", "set-cache-ttl", "12h")
+		assert.NoError(err)
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Equal(12*time.Hour, config.ResolveCacheTTL(values))
+	})
+
+	It("rejects cache ttl values that are not durations", func() {
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err := testhelpers.ExecuteCmd(rootCmd, "config", "set-cache-ttl", "tomorrow")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "cache_ttl must be a positive duration")
+	})
+
 	It("adds global packages when full module paths are provided", func() {
 		runner := &testhelpers.RunnerMock{}
 		tempDir := GinkgoT().TempDir()
@@ -359,7 +453,7 @@ var Config = Describe("config command", func() {
 		assert.NoError(err)
 		values, err := config.Load(configPath)
 		assert.NoError(err)
-		assert.Equal([]string{"github.com/samber/lo"}, values.GlobalPackages)
+		assert.Equal([]string{"github.com/samber/lo"}, config.GlobalPackagePaths(values.GlobalPackages))
 	})
 
 	It("rejects non-full paths for config global-package add", func() {
@@ -385,4 +479,51 @@ var Config = Describe("config command", func() {
 		assert.Error(err)
 		assert.Contains(err.Error(), "full module paths")
 	})
+
+	It("adds, lists and removes templates", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err := testhelpers.ExecuteCmd(rootCmd, "config", "template", "add", "--name", "grpc", "--path", "templates/grpc")
+		assert.NoError(err)
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Equal(map[string]string{"grpc": "templates/grpc"}, values.Templates)
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "config", "template", "list")
+		assert.NoError(err)
+		assert.Contains(output, "grpc\ttemplates/grpc")
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "config", "template", "remove", "--name", "grpc")
+		assert.NoError(err)
+
+		values, err = config.Load(configPath)
+		assert.NoError(err)
+		assert.Empty(values.Templates)
+	})
+
+	It("rejects templates that reuse built-in names", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err := testhelpers.ExecuteCmd(rootCmd, "config", "template", "add", "--name", "api", "--path", "templates/api")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "reserved for a built-in template")
+	})
 })
@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/louiss0/go-toolkit/custom_errors"
+	"github.com/louiss0/go-toolkit/internal/cmdutil"
+	"github.com/louiss0/go-toolkit/internal/globals"
+	"github.com/louiss0/go-toolkit/internal/modindex/config"
+	"github.com/louiss0/go-toolkit/internal/prompt"
+	"github.com/louiss0/go-toolkit/internal/runner"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	modsemver "golang.org/x/mod/semver"
+)
+
+type globalsDriftSummary struct {
+	BinDir     string                    `json:"bin_dir"`
+	Missing    []string                  `json:"missing"`
+	Modified   []string                  `json:"modified"`
+	Unrecorded []unrecordedBinarySummary `json:"unrecorded"`
+}
+
+type unrecordedBinarySummary struct {
+	Path    string `json:"path"`
+	Package string `json:"package"`
+	Version string `json:"version"`
+}
+
+func NewGlobalsCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configPath *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "globals",
+		Short: "Check and reconcile global packages against the bin directory",
+	}
+
+	cmd.AddCommand(
+		newGlobalsDoctorCmd(configPath),
+		newGlobalsSyncCmd(commandRunner, promptRunner, configPath),
+	)
+
+	return cmd
+}
+
+func newGlobalsDoctorCmd(configPath *string) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Report recorded packages that are missing or changed and binaries nobody recorded",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			values, err := config.Load(*configPath)
+			if err != nil {
+				return err
+			}
+
+			drift, err := diagnoseGlobals(*configPath, values)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				if err := cmdutil.WritePrettyJSON(cmd.OutOrStdout(), buildGlobalsDriftSummary(drift)); err != nil {
+					return err
+				}
+			} else if err := cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(globalsDriftLines(drift), "\n")); err != nil {
+				return err
+			}
+
+			if drift.Empty() {
+				return nil
+			}
+
+			problems := len(drift.Missing) + len(drift.Modified) + len(drift.Unrecorded)
+			return custom_errors.CreateInvalidInputErrorWithMessage(
+				fmt.Sprintf("found %d problems; run globals sync to reconcile", problems),
+			)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the report as JSON")
+
+	return cmd
+}
+
+func newGlobalsSyncCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configPath *string) *cobra.Command {
+	var dryRun bool
+	var prune bool
+	var adopt bool
+	var yes bool
+	var jobs int
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Reinstall missing or changed global packages and prune or adopt unrecorded binaries",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if jobs < 1 {
+				return custom_errors.CreateInvalidInputErrorWithMessage("--jobs must be at least 1")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			values, err := config.Load(*configPath)
+			if err != nil {
+				return err
+			}
+
+			drift, err := diagnoseGlobals(*configPath, values)
+			if err != nil {
+				return err
+			}
+
+			// Only Go binaries are pruned; anything else in the bin directory
+			// was not put there by go install.
+			prunable := lo.Filter(drift.Unrecorded, func(binary globals.Binary, _ int) bool {
+				return binary.Package != ""
+			})
+
+			stalePaths := append(append([]string{}, drift.Missing...), drift.Modified...)
+			installArgs, err := resolveGlobalInstallArgs(cmd, values, lo.Filter(values.GlobalPackages, func(pkg config.GlobalPackage, _ int) bool {
+				return lo.Contains(stalePaths, pkg.Path)
+			}))
+			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				lines := lo.Map(installArgs, func(arg string, _ int) string {
+					return "go install " + arg
+				})
+				if prune {
+					lines = append(lines, lo.Map(prunable, func(binary globals.Binary, _ int) string {
+						return "rm " + binary.Path
+					})...)
+				}
+				if adopt {
+					lines = append(lines, lo.FilterMap(drift.Unrecorded, func(binary globals.Binary, _ int) (string, bool) {
+						return "adopt " + binary.Package, binary.Package != ""
+					})...)
+				}
+				if len(lines) == 0 {
+					return cmdutil.WriteLine(cmd.OutOrStdout(), "global packages are in sync")
+				}
+				return cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(lines, "\n"))
+			}
+
+			pruneSkipped := false
+			if prune && len(prunable) > 0 && !yes {
+				confirmed, err := confirmGlobalsPrune(cmd, promptRunner, prunable)
+				if err != nil {
+					return err
+				}
+				pruneSkipped = !confirmed
+			}
+
+			installed, failures := installGlobalPackages(cmd, commandRunner, installArgs, jobs)
+			if err := recordGlobalLock(cmd, values, *configPath, installed, nil); err != nil {
+				return err
+			}
+
+			switch {
+			case prune && !pruneSkipped:
+				for _, binary := range prunable {
+					cmdutil.LogInfoIfProduction("globals sync: removing %s", binary.Path)
+					if err := os.Remove(binary.Path); err != nil {
+						failures = append(failures, fmt.Sprintf("%s: %v", binary.Path, err))
+					}
+				}
+			case adopt:
+				if err := adoptGlobalBinaries(*configPath, values, drift.Unrecorded); err != nil {
+					return err
+				}
+			}
+
+			if len(failures) > 0 {
+				return fmt.Errorf("globals sync finished with %d failures:\n%s", len(failures), strings.Join(failures, "\n"))
+			}
+			if pruneSkipped {
+				return cmdutil.WriteLine(cmd.OutOrStdout(), fmt.Sprintf("kept %d unrecorded binaries; pass --yes to remove them", len(prunable)))
+			}
+
+			return cmdutil.WriteLine(cmd.OutOrStdout(), "global packages are in sync")
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the changes without making them")
+	cmd.Flags().BoolVar(&prune, "prune", false, "delete Go binaries that are not recorded in global_packages")
+	cmd.Flags().BoolVar(&yes, "yes", false, "prune without asking for confirmation")
+	cmd.Flags().BoolVar(&adopt, "adopt", false, "record Go binaries that are not in global_packages yet")
+	cmd.Flags().IntVar(&jobs, "jobs", runtime.NumCPU(), "number of packages to install at the same time")
+	cmd.MarkFlagsMutuallyExclusive("prune", "adopt")
+
+	return cmd
+}
+
+// confirmGlobalsPrune asks before deleting binaries. Without a way to prompt
+// the binaries are kept, so --no-input never deletes anything by itself.
+func confirmGlobalsPrune(cmd *cobra.Command, promptRunner prompt.Runner, binaries []globals.Binary) (bool, error) {
+	confirmed, err := promptRunner.Confirm(cmd, prompt.Confirm{
+		Title: fmt.Sprintf("Remove %d unrecorded binaries?", len(binaries)),
+		Description: strings.Join(lo.Map(binaries, func(binary globals.Binary, _ int) string {
+			return binary.Path
+		}), "\n"),
+	})
+	if errors.Is(err, prompt.ErrNoInput) || errors.Is(err, prompt.ErrPromptsDisabled) || errors.Is(err, huh.ErrUserAborted) {
+		return false, nil
+	}
+
+	return confirmed, err
+}
+
+func diagnoseGlobals(configPath string, values config.Values) (globals.Drift, error) {
+	lock, err := globals.LoadLock(globals.LockPath(configPath))
+	if err != nil {
+		return globals.Drift{}, err
+	}
+
+	binDir := globals.BinDir()
+	cmdutil.LogInfoIfProduction("globals: checking %s", binDir)
+
+	return globals.Diagnose(binDir, config.GlobalPackagePaths(values.GlobalPackages), lock)
+}
+
+// adoptGlobalBinaries records unrecorded Go binaries by the package path
+// embedded in them. Binaries without build info are left alone.
+func adoptGlobalBinaries(configPath string, values config.Values, binaries []globals.Binary) error {
+	adopted := lo.Filter(binaries, func(binary globals.Binary, _ int) bool {
+		return binary.Package != ""
+	})
+	if len(adopted) == 0 {
+		return nil
+	}
+
+	values.GlobalPackages = config.UpsertGlobalPackages(values.GlobalPackages, lo.Map(adopted, func(binary globals.Binary, _ int) config.GlobalPackage {
+		return config.GlobalPackage{Path: binary.Package}
+	})...)
+	if err := config.Save(configPath, values); err != nil {
+		return err
+	}
+
+	lockPath := globals.LockPath(configPath)
+	lock, err := globals.LoadLock(lockPath)
+	if err != nil {
+		return err
+	}
+
+	return globals.SaveLock(lockPath, lock.Upsert(lo.Map(adopted, func(binary globals.Binary, _ int) globals.Entry {
+		return globals.Entry{
+			Path:      binary.Package,
+			Version:   lo.Ternary(modsemver.IsValid(binary.Version), binary.Version, ""),
+			GoVersion: binary.GoVersion,
+			Binary:    filepath.Base(binary.Path),
+			Hash:      binary.Hash,
+		}
+	})...))
+}
+
+// removeGlobalBinaries deletes the installed executables directly, since
+// go clean -i does not touch binaries installed with go install pkg@version,
+// and drops them from the lock file. It keeps going past binaries it cannot
+// remove and returns the packages it did remove along with the joined errors.
+func removeGlobalBinaries(configPath string, packagePaths []string) ([]string, error) {
+	binDir := globals.BinDir()
+	removed := []string{}
+	var errs []error
+	for _, packagePath := range packagePaths {
+		cmdutil.LogInfoIfProduction("removing %s", globalBinaryPath(packagePath))
+		if err := globals.RemoveBinary(binDir, packagePath); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		removed = append(removed, packagePath)
+	}
+
+	lockPath := globals.LockPath(configPath)
+	lock, err := globals.LoadLock(lockPath)
+	if err == nil && len(lock.Packages) > 0 && len(removed) > 0 {
+		err = globals.SaveLock(lockPath, lock.Remove(removed))
+	}
+
+	return removed, errors.Join(append(errs, err)...)
+}
+
+func globalBinaryPath(packagePath string) string {
+	return filepath.Join(globals.BinDir(), globals.BinaryName(packagePath))
+}
+
+func buildGlobalsDriftSummary(drift globals.Drift) globalsDriftSummary {
+	return globalsDriftSummary{
+		BinDir:   drift.BinDir,
+		Missing:  lo.Ternary(drift.Missing == nil, []string{}, drift.Missing),
+		Modified: lo.Ternary(drift.Modified == nil, []string{}, drift.Modified),
+		Unrecorded: lo.Map(drift.Unrecorded, func(binary globals.Binary, _ int) unrecordedBinarySummary {
+			return unrecordedBinarySummary{Path: binary.Path, Package: binary.Package, Version: binary.Version}
+		}),
+	}
+}
+
+func globalsDriftLines(drift globals.Drift) []string {
+	lines := []string{"bin directory: " + drift.BinDir}
+	if drift.Empty() {
+		return append(lines, "no drift found")
+	}
+
+	for _, packagePath := range drift.Missing {
+		lines = append(lines, fmt.Sprintf("missing: %s (%s)", packagePath, globals.BinaryName(packagePath)))
+	}
+	for _, packagePath := range drift.Modified {
+		lines = append(lines, fmt.Sprintf("modified: %s (%s)", packagePath, globals.BinaryName(packagePath)))
+	}
+	for _, binary := range drift.Unrecorded {
+		lines = append(lines, fmt.Sprintf("unrecorded: %s (%s)", binary.Path, lo.CoalesceOrEmpty(binary.Package, "not a Go binary")))
+	}
+
+	return lines
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/louiss0/go-toolkit/custom_errors"
+	"github.com/louiss0/go-toolkit/internal/cmdutil"
+	"github.com/louiss0/go-toolkit/internal/globals"
+	"github.com/louiss0/go-toolkit/internal/modindex/config"
+	"github.com/louiss0/go-toolkit/internal/modproxy"
+	"github.com/louiss0/go-toolkit/internal/search"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	modsemver "golang.org/x/mod/semver"
+)
+
+// recordGlobalLock inspects the binaries go install produced for installArgs
+// and saves them to the lock file next to the config. When keep is not nil,
+// lock entries for other packages are dropped.
+func recordGlobalLock(cmd *cobra.Command, values config.Values, configPath string, installArgs []string, keep []string) error {
+	lockPath := globals.LockPath(configPath)
+	lock, err := globals.LoadLock(lockPath)
+	if err != nil {
+		return err
+	}
+
+	var client *modproxy.Client
+	entries := []globals.Entry{}
+	for _, arg := range installArgs {
+		packagePath, requestedVersion, _ := strings.Cut(arg, "@")
+		binaryName := globals.BinaryName(packagePath)
+		binary, err := globals.InspectBinary(filepath.Join(globals.BinDir(), binaryName))
+		if err != nil {
+			cmdutil.LogInfoIfProduction("lock: skipping %s: %v", packagePath, err)
+			continue
+		}
+
+		version, err := lockedVersion(cmd, values, &client, packagePath, requestedVersion, binary)
+		if err != nil {
+			cmdutil.LogInfoIfProduction("lock: skipping %s: %v", packagePath, err)
+			continue
+		}
+
+		entries = append(entries, globals.Entry{
+			Path:      packagePath,
+			Module:    binary.Module,
+			Version:   version,
+			GoVersion: binary.GoVersion,
+			Binary:    binaryName,
+			Hash:      binary.Hash,
+		})
+	}
+
+	lock = lock.Upsert(entries...)
+	if keep != nil {
+		lock = lock.Retain(keep)
+	}
+	if len(entries) == 0 && keep == nil {
+		return nil
+	}
+
+	return globals.SaveLock(lockPath, lock)
+}
+
+// lockedVersion returns the concrete version to record for a binary. Binaries
+// that report a pseudo label such as (devel) fall back to the requested
+// version, and queries like latest resolve on the module proxy so a frozen
+// install never reinstalls a moving target.
+func lockedVersion(cmd *cobra.Command, values config.Values, client **modproxy.Client, packagePath string, requestedVersion string, binary globals.Binary) (string, error) {
+	if modsemver.IsValid(binary.Version) {
+		return binary.Version, nil
+	}
+	if modsemver.IsValid(requestedVersion) && modsemver.Canonical(requestedVersion) == requestedVersion {
+		return requestedVersion, nil
+	}
+
+	if *client == nil {
+		searchClient, err := newSearchClient(values, false)
+		if err != nil {
+			return "", err
+		}
+		*client = &searchClient
+	}
+
+	return search.ResolvePackageVersion(cmd.Context(), **client, packagePath, requestedVersion)
+}
+
+// frozenInstallArgs installs exactly what the lock records and refuses to run
+// when the config asks for packages or pins the lock does not cover.
+func frozenInstallArgs(lock globals.Lock, values config.Values) ([]string, error) {
+	if len(lock.Packages) == 0 {
+		return nil, custom_errors.CreateInvalidInputErrorWithMessage(
+			fmt.Sprintf("no %s entries found; run install-globals without --frozen first", globals.LockFileName),
+		)
+	}
+
+	problems := []string{}
+	for _, globalPackage := range values.GlobalPackages {
+		entry, found := lock.Find(globalPackage.Path)
+		switch {
+		case !found:
+			problems = append(problems, fmt.Sprintf("%s is not locked", globalPackage.Path))
+		case globalPackage.IsExact() && entry.Version != globalPackage.Version:
+			problems = append(problems, fmt.Sprintf("%s is pinned to %s but locked at %s", globalPackage.Path, globalPackage.Version, entry.Version))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, custom_errors.CreateInvalidInputErrorWithMessage(
+			fmt.Sprintf("%s is out of date: %s; run install-globals without --frozen", globals.LockFileName, strings.Join(problems, ", ")),
+		)
+	}
+
+	return lo.Map(lock.Packages, func(entry globals.Entry, _ int) string {
+		return entry.Path + "@" + entry.Version
+	}), nil
+}
+
+// verifyFrozenBinaries checks the build info of each installed binary against
+// its lock entry. go install output is not byte for byte reproducible across
+// platforms and build environments, so a different hash is only reported.
+func verifyFrozenBinaries(lock globals.Lock) error {
+	problems := []string{}
+	for _, entry := range lock.Packages {
+		binary, err := globals.InspectBinary(filepath.Join(globals.BinDir(), entry.Binary))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", entry.Binary, err))
+			continue
+		}
+
+		switch {
+		case binary.Package != entry.Path:
+			problems = append(problems, fmt.Sprintf("%s was built from %s, locked as %s", entry.Binary, lo.CoalesceOrEmpty(binary.Package, "an unknown package"), entry.Path))
+		case entry.Module != "" && binary.Module != entry.Module:
+			problems = append(problems, fmt.Sprintf("%s was built from module %s, locked as %s", entry.Binary, binary.Module, entry.Module))
+		case binary.Version != entry.Version:
+			problems = append(problems, fmt.Sprintf("%s is at %s, locked at %s", entry.Binary, binary.Version, entry.Version))
+		case entry.GoVersion != "" && binary.GoVersion != entry.GoVersion:
+			problems = append(problems, fmt.Sprintf("%s was built with %s, locked with %s", entry.Binary, binary.GoVersion, entry.GoVersion))
+		case binary.Hash != entry.Hash:
+			cmdutil.LogInfoIfProduction("install-globals: %s matches its lock entry but its hash differs from the locked %s", entry.Binary, entry.Hash)
+		}
+	}
+	if len(problems) > 0 {
+		return custom_errors.CreateInvalidInputErrorWithMessage(strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/louiss0/go-toolkit/cmd"
+	"github.com/louiss0/go-toolkit/internal/globals"
+	"github.com/louiss0/go-toolkit/internal/modindex/config"
+	"github.com/louiss0/go-toolkit/internal/testhelpers"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var Globals = Describe("globals command", func() {
+	assert := assert.New(GinkgoT())
+
+	var binDir string
+	var configPath string
+
+	BeforeEach(func() {
+		binDir = GinkgoT().TempDir()
+		GinkgoT().Setenv("GOBIN", binDir)
+
+		configPath = filepath.Join(GinkgoT().TempDir(), "config.toml")
+		err := os.WriteFile(configPath, []byte("global_packages = [\"github.com/samber/lo@v1.49.1\", \"mvdan.cc/gofumpt@v0.7.0\"]\n"), 0o644)
+		assert.NoError(err)
+		err = os.WriteFile(filepath.Join(binDir, globals.BinaryName("github.com/samber/lo")), []byte("lo"), 0o755)
+		assert.NoError(err)
+		err = os.WriteFile(filepath.Join(binDir, "stray"), []byte("stray"), 0o755)
+		assert.NoError(err)
+	})
+
+	It("reports drift and fails", func() {
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+		output := new(bytes.Buffer)
+		rootCmd.SetOut(output)
+		rootCmd.SetErr(new(bytes.Buffer))
+		rootCmd.SetArgs([]string{"globals", "doctor"})
+
+		err := rootCmd.Execute()
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "found 2 problems")
+		assert.Contains(output.String(), "bin directory: "+binDir)
+		assert.Contains(output.String(), "missing: mvdan.cc/gofumpt")
+		assert.Contains(output.String(), "unrecorded: "+filepath.Join(binDir, "stray")+" (not a Go binary)")
+	})
+
+	It("passes when nothing drifted", func() {
+		err := os.Remove(filepath.Join(binDir, "stray"))
+		assert.NoError(err)
+		err = os.WriteFile(configPath, []byte("global_packages = [\"github.com/samber/lo\"]\n"), 0o644)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "globals", "doctor")
+
+		assert.NoError(err)
+		assert.Contains(output, "no drift found")
+	})
+
+	It("reinstalls missing packages and prunes unrecorded Go binaries", func() {
+		toolPath := filepath.Join(binDir, "tool")
+		err := testhelpers.BuildGoBinary(toolPath, "example.com/tools/tool")
+		assert.NoError(err)
+
+		runner := &testhelpers.RunnerMock{}
+		runner.On("Run", mock.Anything, "go", []string{"install", "mvdan.cc/gofumpt@v0.7.0"}).Return(nil).Once()
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "globals", "sync", "--prune", "--yes")
+
+		assert.NoError(err)
+		assert.Contains(output, "global packages are in sync")
+		runner.AssertExpectations(GinkgoT())
+		assert.NoFileExists(toolPath)
+		assert.FileExists(filepath.Join(binDir, "stray"))
+		assert.FileExists(filepath.Join(binDir, globals.BinaryName("github.com/samber/lo")))
+	})
+
+	It("asks before pruning", func() {
+		toolPath := filepath.Join(binDir, "tool")
+		err := testhelpers.BuildGoBinary(toolPath, "example.com/tools/tool")
+		assert.NoError(err)
+
+		runner := &testhelpers.RunnerMock{}
+		runner.On("Run", mock.Anything, "go", []string{"install", "mvdan.cc/gofumpt@v0.7.0"}).Return(nil).Once()
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner: runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(
+				testhelpers.PromptStep{Kind: testhelpers.PromptStepConfirm, Confirmed: false},
+			),
+			ConfigPath: configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "globals", "sync", "--prune")
+
+		assert.NoError(err)
+		assert.Contains(output, "kept 1 unrecorded binaries; pass --yes to remove them")
+		assert.FileExists(toolPath)
+	})
+
+	It("keeps binaries when prompts are unavailable", func() {
+		toolPath := filepath.Join(binDir, "tool")
+		err := testhelpers.BuildGoBinary(toolPath, "example.com/tools/tool")
+		assert.NoError(err)
+
+		runner := &testhelpers.RunnerMock{}
+		runner.On("Run", mock.Anything, "go", []string{"install", "mvdan.cc/gofumpt@v0.7.0"}).Return(nil).Once()
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "globals", "sync", "--prune", "--no-input")
+
+		assert.NoError(err)
+		assert.Contains(output, "kept 1 unrecorded binaries")
+		assert.FileExists(toolPath)
+	})
+
+	It("adopts unrecorded Go binaries", func() {
+		executable, err := os.Executable()
+		assert.NoError(err)
+		content, err := os.ReadFile(executable)
+		assert.NoError(err)
+		err = os.WriteFile(filepath.Join(binDir, "stray"), content, 0o755)
+		assert.NoError(err)
+		binary, err := globals.InspectBinary(filepath.Join(binDir, "stray"))
+		assert.NoError(err)
+
+		runner := &testhelpers.RunnerMock{}
+		runner.On("Run", mock.Anything, "go", []string{"install", "mvdan.cc/gofumpt@v0.7.0"}).Return(nil).Once()
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "globals", "sync", "--adopt")
+
+		assert.NoError(err)
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Contains(config.GlobalPackagePaths(values.GlobalPackages), binary.Package)
+		lock, err := globals.LoadLock(globals.LockPath(configPath))
+		assert.NoError(err)
+		entry, found := lock.Find(binary.Package)
+		assert.True(found)
+		assert.Equal(binary.Hash, entry.Hash)
+	})
+
+	It("prints planned changes on dry run", func() {
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "globals", "sync", "--prune", "--dry-run")
+
+		assert.NoError(err)
+		assert.Equal("go install mvdan.cc/gofumpt@v0.7.0\n", output)
+		assert.FileExists(filepath.Join(binDir, "stray"))
+	})
+})
@@ -2,20 +2,24 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/louiss0/go-toolkit/custom_errors"
 	"github.com/louiss0/go-toolkit/custom_flags"
 	"github.com/louiss0/go-toolkit/internal/cmdutil"
+	"github.com/louiss0/go-toolkit/internal/gomod"
 	"github.com/louiss0/go-toolkit/internal/modindex/config"
 	"github.com/louiss0/go-toolkit/internal/packagepath"
 	"github.com/louiss0/go-toolkit/internal/project"
 	"github.com/louiss0/go-toolkit/internal/prompt"
 	"github.com/louiss0/go-toolkit/internal/runner"
 	"github.com/louiss0/go-toolkit/validation"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
@@ -23,8 +27,13 @@ func NewInitCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configP
 	siteFlag := custom_flags.NewEmptyStringFlag("site")
 	userFlag := custom_flags.NewEmptyStringFlag("user")
 	gitFlag := custom_flags.NewBoolFlag("git")
-	templateFlag := custom_flags.NewUnionFlag(project.TemplateValues(), "template")
+	templateFlag := custom_flags.NewEmptyStringFlag("template")
+	onConflictFlag := custom_flags.NewUnionFlag(project.ConflictPolicies(), "on-conflict")
 	var allowFull bool
+	var force bool
+	var dryRun bool
+	var useWorkspace bool
+	var verify bool
 	var packageFlags []string
 	var presetFlags []string
 
@@ -32,13 +41,23 @@ func NewInitCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configP
 		Use:   "init [folder]",
 		Short: "Initialize a Go module in a target folder",
 		Args:  cobra.MaximumNArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			transaction := newInitTransaction()
+			defer func() {
+				err = transaction.Rollback(err)
+			}()
+
 			values, err := config.Load(*configPath)
 			if err != nil {
 				return err
 			}
 
-			inputs, err := collectInitInput(cmd, args, promptRunner, values, siteFlag.String(), userFlag.String(), gitFlag.String())
+			templateDirs := config.ResolveTemplateDirs(*configPath, values)
+			if err := validateInitTemplate(templateFlag.String(), templateDirs); err != nil {
+				return err
+			}
+
+			inputs, err := collectInitInput(cmd, args, promptRunner, values, templateDirs, siteFlag.String(), userFlag.String(), gitFlag.String())
 			if err != nil {
 				if errors.Is(err, huh.ErrUserAborted) {
 					return nil
@@ -46,7 +65,7 @@ func NewInitCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configP
 				return err
 			}
 
-			values, err = persistInitConfig(*configPath, values, inputs.Prompt)
+			values, configChanges, err := persistInitConfig(*configPath, values, inputs.Prompt, dryRun, transaction)
 			if err != nil {
 				return err
 			}
@@ -56,7 +75,13 @@ func NewInitCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configP
 				return err
 			}
 
-			installPackages, err := resolveInitPackages(cmd, promptRunner, values, site, user, packageFlags, presetFlags, inputs.Prompt.Packages)
+			template := resolveInitTemplate(templateFlag.String(), inputs.Prompt)
+			manifest, err := project.LoadManifest(template, templateDirs)
+			if err != nil {
+				return err
+			}
+
+			inputs.Prompt.TemplateAnswers, err = promptTemplateAnswers(cmd, promptRunner, manifest)
 			if err != nil {
 				if errors.Is(err, huh.ErrUserAborted) {
 					return nil
@@ -64,20 +89,73 @@ func NewInitCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configP
 				return err
 			}
 
-			modulePath, err := initModule(cmd, commandRunner, inputs.TargetPath, inputs.ModuleInput, site, user, installPackages)
+			requestedPackages := append(append([]string{}, packageFlags...), manifest.Packages...)
+			installPackages, err := resolveInitPackages(cmd, promptRunner, values, site, user, requestedPackages, presetFlags, inputs.Prompt.Packages)
+			if err != nil {
+				if errors.Is(err, huh.ErrUserAborted) {
+					return nil
+				}
+				return err
+			}
+			if verify {
+				if err := verifyModulePaths(cmd, values, installPackages); err != nil {
+					return err
+				}
+			}
+
+			cmdutil.LogInfoIfProduction("init: resolving module path for %s", site)
+			modulePath, err := packagepath.ResolveModulePath(inputs.ModuleInput, site, user)
 			if err != nil {
 				return err
 			}
 
-			template := resolveInitTemplate(templateFlag.String(), inputs.Prompt)
 			shouldInitGit := resolveInitGit(gitFlag.String(), gitFlag.Value(), values, inputs.Prompt)
-			if err := applyInitLayout(cmd, commandRunner, inputs.TargetPath, template, shouldInitGit); err != nil {
+			templateData := project.NewTemplateData(modulePath, site, user, installPackages)
+			templateData.Answers = inputs.Prompt.TemplateAnswers
+			layoutOptions := project.Options{
+				Template:       template,
+				Templates:      templateDirs,
+				WriteGitIgnore: shouldInitGit,
+				Data:           templateData,
+				OnConflict:     resolveInitConflictPolicy(onConflictFlag.String(), force),
+			}
+
+			var workspaceArgs []string
+			if useWorkspace {
+				workspaceArgs, err = workspaceUseArgs(inputs.TargetPath)
+				if err != nil {
+					return err
+				}
+			}
+
+			if dryRun {
+				cmdutil.LogInfoIfProduction("init: dry run output")
+				return writeInitPlan(cmd, inputs.TargetPath, layoutOptions, manifest, shouldInitGit, workspaceArgs, configChanges)
+			}
+
+			if err := initModule(cmd, commandRunner, transaction, inputs.TargetPath, modulePath, installPackages); err != nil {
+				return err
+			}
+
+			if len(workspaceArgs) > 0 {
+				if err := addInitModuleToWorkspace(cmd, commandRunner, transaction, workspaceArgs); err != nil {
+					return err
+				}
+			}
+
+			layout, err := applyInitLayout(cmd, commandRunner, promptRunner, transaction, inputs.TargetPath, layoutOptions, shouldInitGit)
+			if err != nil {
+				return err
+			}
+
+			if err := runTemplateHooks(cmd, commandRunner, inputs.TargetPath, manifest, templateData); err != nil {
 				return err
 			}
 
 			inputs.Prompt.Packages = installPackages
+			transaction.Commit()
 
-			return writeInitSummary(cmd, modulePath, site, user, template, shouldInitGit, inputs.Prompt)
+			return writeInitSummary(cmd, modulePath, site, user, template, shouldInitGit, inputs.Prompt, layout.Conflicts)
 		},
 	}
 
@@ -88,9 +166,21 @@ func NewInitCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configP
 	cmd.Flags().Var(&templateFlag, "template", "project template to apply")
 	cmd.Flags().StringSliceVar(&packageFlags, "package", nil, "module paths to install after init")
 	cmd.Flags().StringSliceVar(&presetFlags, "preset", nil, "package preset names to install after init")
+	cmd.Flags().Var(&onConflictFlag, "on-conflict", "how to handle existing files: skip, overwrite, prompt, or new")
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite existing files")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the init plan without changing anything")
+	cmd.Flags().BoolVar(&useWorkspace, "workspace", false, "add the new module to the nearest go.work")
+	cmd.Flags().BoolVar(&verify, "verify", false, "check that each package exists on the module proxy first")
+	cmd.MarkFlagsMutuallyExclusive("force", "on-conflict")
 	cmdutil.RegisterSiteCompletion(cmd, "site")
+	_ = cmd.RegisterFlagCompletionFunc("on-conflict", cobra.FixedCompletions(project.ConflictPolicies(), cobra.ShellCompDirectiveNoFileComp))
 	_ = cmd.RegisterFlagCompletionFunc("template", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
-		return project.TemplateValues(), cobra.ShellCompDirectiveNoFileComp
+		values, err := config.Load(*configPath)
+		if err != nil {
+			return project.TemplateValues(), cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return project.TemplateNames(values.Templates), cobra.ShellCompDirectiveNoFileComp
 	})
 
 	return cmd
@@ -102,7 +192,7 @@ type initInput struct {
 	ModuleInput string
 }
 
-func collectInitInput(cmd *cobra.Command, args []string, promptRunner prompt.Runner, values config.Values, flagSite string, flagUser string, flagGit string) (initInput, error) {
+func collectInitInput(cmd *cobra.Command, args []string, promptRunner prompt.Runner, values config.Values, templateDirs map[string]string, flagSite string, flagUser string, flagGit string) (initInput, error) {
 	if len(args) > 0 {
 		targetPath, err := validation.RequiredString(args[0], "folder")
 		if err != nil {
@@ -110,6 +200,9 @@ func collectInitInput(cmd *cobra.Command, args []string, promptRunner prompt.Run
 		}
 
 		promptValues, err := promptInitConfigInputs(cmd, promptRunner, values, flagSite, flagUser, flagGit)
+		if errors.Is(err, prompt.ErrNoInput) {
+			promptValues, err = initPrompt{}, nil
+		}
 		if err != nil {
 			return initInput{}, err
 		}
@@ -129,9 +222,9 @@ func collectInitInput(cmd *cobra.Command, args []string, promptRunner prompt.Run
 	}
 
 	cmdutil.LogInfoIfProduction("init: starting interactive prompt")
-	promptValues, err := promptInitInputs(cmd, promptRunner)
+	promptValues, err := promptInitInputs(cmd, promptRunner, values, project.TemplateNames(templateDirs))
 	if err != nil {
-		return initInput{}, err
+		return initInput{}, cmdutil.MissingInput(err, "missing folder; pass a folder argument or answer the prompts with --answers")
 	}
 
 	moduleInput, err := validation.RequiredString(promptValues.ModuleName, "module name")
@@ -146,40 +239,45 @@ func collectInitInput(cmd *cobra.Command, args []string, promptRunner prompt.Run
 	}, nil
 }
 
-func persistInitConfig(configPath string, values config.Values, promptValues initPrompt) (config.Values, error) {
-	if !applyInitPromptConfig(&values, promptValues) {
-		return values, nil
+func persistInitConfig(configPath string, values config.Values, promptValues initPrompt, dryRun bool, transaction *initTransaction) (config.Values, []initConfigChange, error) {
+	changes := applyInitPromptConfig(&values, promptValues)
+	if len(changes) == 0 || dryRun {
+		return values, changes, nil
+	}
+
+	if err := transaction.Track(configPath); err != nil {
+		return config.Values{}, nil, err
 	}
 
 	if err := config.Save(configPath, values); err != nil {
-		return config.Values{}, err
+		return config.Values{}, nil, err
 	}
 
-	return values, nil
+	return values, changes, nil
 }
 
-func applyInitPromptConfig(values *config.Values, promptValues initPrompt) bool {
-	configChanged := false
+func applyInitPromptConfig(values *config.Values, promptValues initPrompt) []initConfigChange {
+	changes := []initConfigChange{}
 
 	if promptValues.UserName != "" {
 		values.User = promptValues.UserName
-		configChanged = true
+		changes = append(changes, initConfigChange{Key: "user", Value: values.User})
 	}
 	if promptValues.ProviderSite != "" {
 		values.Site = promptValues.ProviderSite
-		configChanged = true
+		changes = append(changes, initConfigChange{Key: "site", Value: values.Site})
 	}
 	if promptValues.ShouldPersistTestChoice() {
 		values.Scaffold.WriteTests = promptValues.TestDrivenChoice == testChoiceYes
-		configChanged = true
+		changes = append(changes, initConfigChange{Key: "scaffold.write_tests", Value: values.Scaffold.WriteTests})
 	}
 	if promptValues.ShouldPersistGitChoice() {
 		initGit := promptValues.GitChoice == gitChoiceYes
 		values.Scaffold.InitGit = &initGit
-		configChanged = true
+		changes = append(changes, initConfigChange{Key: "scaffold.init_git", Value: initGit})
 	}
 
-	return configChanged
+	return changes
 }
 
 func resolveInitSiteAndUser(flagSite string, flagUser string, allowFull bool, values config.Values) (string, string, error) {
@@ -216,77 +314,336 @@ func resolveInitPackages(cmd *cobra.Command, promptRunner prompt.Runner, values
 	return resolveModulePaths(installPackages, site, user)
 }
 
-func initModule(cmd *cobra.Command, commandRunner runner.Runner, targetPath string, moduleInput string, site string, user string, installPackages []string) (string, error) {
-	cmdutil.LogInfoIfProduction("init: resolving module path for %s", site)
-	modulePath, err := packagepath.ResolveModulePath(moduleInput, site, user)
-	if err != nil {
-		return "", err
+func initModule(cmd *cobra.Command, commandRunner runner.Runner, transaction *initTransaction, targetPath string, modulePath string, installPackages []string) error {
+	for _, path := range []string{targetPath, filepath.Join(targetPath, "go.mod"), filepath.Join(targetPath, "go.sum")} {
+		if err := transaction.Track(path); err != nil {
+			return err
+		}
 	}
 
 	if err := os.MkdirAll(targetPath, 0o755); err != nil {
-		return "", err
+		return err
+	}
+
+	for _, command := range initModuleCommands(targetPath, modulePath, installPackages) {
+		cmdutil.LogInfoIfProduction("init: running %s", command)
+		if err := commandRunner.Run(cmd, command.Name, command.Args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addInitModuleToWorkspace(cmd *cobra.Command, commandRunner runner.Runner, transaction *initTransaction, workspaceArgs []string) error {
+	if err := transaction.Track(filepath.Join(workspaceArgs[1], gomod.WorkFileName)); err != nil {
+		return err
+	}
+
+	cmdutil.LogInfoIfProduction("init: adding module to go.work")
+	return commandRunner.Run(cmd, "go", workspaceArgs...)
+}
+
+type initCommand struct {
+	Dir  string
+	Name string
+	Args []string
+}
+
+func (c initCommand) String() string {
+	return strings.Join(append([]string{c.Name}, c.Args...), " ")
+}
+
+func initModuleCommands(targetPath string, modulePath string, installPackages []string) []initCommand {
+	commands := []initCommand{
+		{Name: "go", Args: []string{"-C", targetPath, "mod", "init", modulePath}},
 	}
 
-	cmdutil.LogInfoIfProduction("init: running go mod init")
-	if err := commandRunner.Run(cmd, "go", "-C", targetPath, "mod", "init", modulePath); err != nil {
-		return "", err
+	if len(installPackages) > 0 {
+		commands = append(commands, initCommand{
+			Name: "go",
+			Args: append([]string{"-C", targetPath, "get"}, installPackages...),
+		})
+	}
+
+	return commands
+}
+
+func validateInitTemplate(template string, templateDirs map[string]string) error {
+	if template == "" {
+		return nil
+	}
+
+	if _, custom := templateDirs[template]; custom && project.IsBuiltinTemplate(template) {
+		return custom_errors.CreateInvalidInputErrorWithMessage(
+			fmt.Sprintf("template %s conflicts with a built-in template; rename it in the config", template),
+		)
+	}
+
+	if !project.HasTemplate(template, templateDirs) {
+		return custom_errors.CreateInvalidInputErrorWithMessage(
+			fmt.Sprintf("template must be one of: %s", strings.Join(project.TemplateNames(templateDirs), ", ")),
+		)
+	}
+
+	return nil
+}
+
+func resolveInitConflictPolicy(flagValue string, force bool) string {
+	if force {
+		return project.ConflictOverwrite
 	}
 
-	if len(installPackages) == 0 {
-		return modulePath, nil
+	if flagValue != "" {
+		return flagValue
+	}
+
+	return project.ConflictSkip
+}
+
+func applyInitLayout(cmd *cobra.Command, commandRunner runner.Runner, promptRunner prompt.Runner, transaction *initTransaction, targetPath string, options project.Options, shouldInitGit bool) (project.LayoutResult, error) {
+	plan, err := project.PlanLayout(targetPath, options)
+	if err != nil {
+		return project.LayoutResult{}, err
+	}
+
+	if conflicts := plan.Conflicts(); len(conflicts) > 0 {
+		// Report conflicts before any prompt or write, whatever the log level.
+		message := fmt.Sprintf("existing files differ from the %s template:\n  %s", options.Template, strings.Join(conflicts, "\n  "))
+		if err := cmdutil.WriteLine(cmd.ErrOrStderr(), message); err != nil {
+			return project.LayoutResult{}, err
+		}
+	}
+
+	options.ResolveConflict = func(path string) (string, error) {
+		choice, err := promptRunner.Select(cmd, prompt.Select{
+			Title: fmt.Sprintf("%s already exists", path),
+			Options: []prompt.Option{
+				{Label: "Skip", Value: project.ConflictSkip},
+				{Label: "Overwrite", Value: project.ConflictOverwrite},
+				{Label: fmt.Sprintf("Write %s.new", path), Value: project.ConflictNew},
+			},
+		})
+		if errors.Is(err, prompt.ErrNoInput) {
+			return project.ConflictSkip, nil
+		}
+		return choice, err
 	}
 
-	cmdutil.LogInfoIfProduction("init: installing packages")
-	args := append([]string{"-C", targetPath, "get"}, installPackages...)
-	if err := commandRunner.Run(cmd, "go", args...); err != nil {
-		return "", err
+	options.BeforeWrite = transaction.Track
+
+	cmdutil.LogInfoIfProduction("init: creating project layout from %s template", options.Template)
+	layout, err := project.EnsureLayout(targetPath, options)
+	if err != nil {
+		return project.LayoutResult{}, err
+	}
+
+	gitInit, err := needsGitInit(targetPath, shouldInitGit)
+	if err != nil {
+		return project.LayoutResult{}, err
+	}
+	if !gitInit {
+		cmdutil.LogInfoIfProduction("init: git init skipped")
+		return layout, nil
+	}
+
+	if err := transaction.Track(filepath.Join(targetPath, ".git")); err != nil {
+		return project.LayoutResult{}, err
+	}
+
+	cmdutil.LogInfoIfProduction("init: running git init")
+	return layout, commandRunner.Run(cmd, "git", "-C", targetPath, "init")
+}
+
+func promptTemplateAnswers(cmd *cobra.Command, runner prompt.Runner, manifest project.Manifest) (map[string]any, error) {
+	answers := map[string]any{}
+
+	for _, manifestPrompt := range manifest.Prompts {
+		answer, err := promptTemplateAnswer(cmd, runner, manifestPrompt)
+		if errors.Is(err, prompt.ErrNoInput) {
+			answer, err = templatePromptDefault(manifestPrompt)
+		}
+		if err != nil {
+			return nil, err
+		}
+		answers[manifestPrompt.Key] = answer
+	}
+
+	return answers, nil
+}
+
+func promptTemplateAnswer(cmd *cobra.Command, runner prompt.Runner, manifestPrompt project.ManifestPrompt) (any, error) {
+	switch manifestPrompt.PromptType() {
+	case project.PromptConfirm:
+		return runner.Confirm(cmd, prompt.Confirm{
+			Title:       manifestPrompt.PromptTitle(),
+			Description: manifestPrompt.Description,
+		})
+	case project.PromptSelect:
+		return runner.Select(cmd, prompt.Select{
+			Title: manifestPrompt.PromptTitle(),
+			Options: lo.Map(manifestPrompt.Options, func(option string, _ int) prompt.Option {
+				return prompt.Option{Label: option, Value: option}
+			}),
+		})
+	default:
+		value, err := runner.Input(cmd, prompt.Input{
+			Title:       manifestPrompt.PromptTitle(),
+			Description: manifestPrompt.Description,
+			Placeholder: manifestPrompt.Default,
+			Validate: func(value string) error {
+				if !manifestPrompt.Required {
+					return nil
+				}
+				_, err := validation.RequiredString(value, manifestPrompt.PromptTitle())
+				return err
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return lo.Ternary(strings.TrimSpace(value) == "", manifestPrompt.Default, strings.TrimSpace(value)), nil
 	}
+}
 
-	return modulePath, nil
+func templatePromptDefault(manifestPrompt project.ManifestPrompt) (any, error) {
+	switch manifestPrompt.PromptType() {
+	case project.PromptConfirm:
+		confirmed, _ := strconv.ParseBool(manifestPrompt.Default)
+		return confirmed, nil
+	case project.PromptSelect:
+		if manifestPrompt.Default != "" {
+			return manifestPrompt.Default, nil
+		}
+		return lo.FirstOrEmpty(manifestPrompt.Options), nil
+	default:
+		if manifestPrompt.Required && manifestPrompt.Default == "" {
+			key := prompt.AnswerKey(manifestPrompt.PromptTitle())
+			return nil, custom_errors.CreateInvalidInputErrorWithMessage(
+				fmt.Sprintf("missing template answer %q; set %s with --answers or %s%s", manifestPrompt.PromptTitle(), key, prompt.AnswerEnvPrefix, strings.ToUpper(key)),
+			)
+		}
+		return manifestPrompt.Default, nil
+	}
 }
 
-func applyInitLayout(cmd *cobra.Command, commandRunner runner.Runner, targetPath string, template string, shouldInitGit bool) error {
-	cmdutil.LogInfoIfProduction("init: creating project layout from %s template", template)
-	if err := project.EnsureLayout(targetPath, project.Options{
-		Template:       template,
-		WriteGitIgnore: shouldInitGit,
-	}); err != nil {
+func runTemplateHooks(cmd *cobra.Command, commandRunner runner.Runner, targetPath string, manifest project.Manifest, templateData project.TemplateData) error {
+	commands, err := templateHookCommands(targetPath, manifest, templateData)
+	if err != nil {
 		return err
 	}
 
+	for _, command := range commands {
+		cmdutil.LogInfoIfProduction("init: running %s hook", command.Name)
+		if err := commandRunner.RunInDir(cmd, command.Dir, command.Name, command.Args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func templateHookCommands(targetPath string, manifest project.Manifest, templateData project.TemplateData) ([]initCommand, error) {
+	commands := []initCommand{}
+	for _, hook := range manifest.ActiveHooks(templateData.Answers) {
+		args, err := project.RenderArgs(hook.Args, templateData)
+		if err != nil {
+			return nil, err
+		}
+
+		commands = append(commands, initCommand{Dir: targetPath, Name: hook.Command, Args: args})
+	}
+
+	return commands, nil
+}
+
+func needsGitInit(targetPath string, shouldInitGit bool) (bool, error) {
 	if !shouldInitGit {
-		cmdutil.LogInfoIfProduction("init: git init skipped")
-		return nil
+		return false, nil
 	}
 
 	if _, err := os.Stat(filepath.Join(targetPath, ".git")); err == nil {
-		cmdutil.LogInfoIfProduction("init: git already initialized")
-		return nil
+		return false, nil
 	} else if !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func writeInitPlan(cmd *cobra.Command, targetPath string, options project.Options, manifest project.Manifest, shouldInitGit bool, workspaceArgs []string, configChanges []initConfigChange) error {
+	layoutPlan, err := project.PlanLayout(targetPath, options)
+	if err != nil {
 		return err
 	}
 
-	cmdutil.LogInfoIfProduction("init: running git init")
-	return commandRunner.Run(cmd, "git", "-C", targetPath, "init")
+	commands := initModuleCommands(targetPath, options.Data.ModulePath, options.Data.Packages)
+	if len(workspaceArgs) > 0 {
+		commands = append(commands, initCommand{Name: "go", Args: workspaceArgs})
+	}
+
+	gitInit, err := needsGitInit(targetPath, shouldInitGit)
+	if err != nil {
+		return err
+	}
+	if gitInit {
+		commands = append(commands, initCommand{Name: "git", Args: []string{"-C", targetPath, "init"}})
+	}
+
+	hookCommands, err := templateHookCommands(targetPath, manifest, options.Data)
+	if err != nil {
+		return err
+	}
+	commands = append(commands, hookCommands...)
+
+	packages := options.Data.Packages
+	if packages == nil {
+		packages = []string{}
+	}
+
+	plan := initPlan{
+		ModulePath:  options.Data.ModulePath,
+		Site:        options.Data.Site,
+		User:        options.Data.User,
+		ProjectType: options.Template,
+		GitInit:     shouldInitGit,
+		Packages:    packages,
+		Answers:     options.Data.Answers,
+		Files: lo.Map(layoutPlan.Files, func(file project.PlannedFile, _ int) initPlanFile {
+			return initPlanFile{Path: file.Path, Action: planFileAction(file, options.OnConflict)}
+		}),
+		Commands: lo.Map(commands, func(command initCommand, _ int) initPlanCommand {
+			return initPlanCommand{Dir: command.Dir, Command: command.String()}
+		}),
+		ConfigChanges: configChanges,
+	}
+
+	return cmdutil.WritePrettyJSON(cmd.OutOrStdout(), plan)
+}
+
+func planFileAction(file project.PlannedFile, onConflict string) string {
+	switch {
+	case !file.Exists:
+		return "create"
+	case !file.Changed:
+		return "unchanged"
+	default:
+		return onConflict
+	}
 }
 
 const (
-	templateTypeAPI           = project.TemplateAPI
-	templateTypeCLI           = project.TemplateCLI
-	templateTypeLib           = project.TemplateLib
-	templateTypeSkip          = "skip"
-	templateTypeSkipRemaining = "skip-remaining"
-	providerCustom            = "custom"
-	providerSkip              = "skip"
-	providerSkipRemaining     = "skip-remaining"
-	testChoiceYes             = "yes"
-	testChoiceNo              = "no"
-	testChoiceSkip            = "skip"
-	testChoiceSkipRemaining   = "skip-remaining"
-	gitChoiceYes              = "yes"
-	gitChoiceNo               = "no"
-	gitChoiceSkip             = "skip"
-	gitChoiceSkipRemaining    = "skip-remaining"
+	templateTypeAPI       = project.TemplateAPI
+	templateTypeCLI       = project.TemplateCLI
+	templateTypeLib       = project.TemplateLib
+	providerCustom        = "custom"
+	providerSkip          = "skip"
+	providerSkipRemaining = "skip-remaining"
+	testChoiceYes         = "yes"
+	testChoiceNo          = "no"
+	testChoiceSkip        = "skip"
+	gitChoiceYes          = "yes"
+	gitChoiceNo           = "no"
 )
 
 type initPrompt struct {
@@ -297,6 +654,7 @@ type initPrompt struct {
 	TestDrivenChoice string
 	GitChoice        string
 	Packages         []string
+	TemplateAnswers  map[string]any
 	Used             bool
 }
 
@@ -317,177 +675,236 @@ func (p initPrompt) ShouldPersistGitChoice() bool {
 }
 
 type initSummary struct {
-	ModulePath  string   `json:"module_path"`
-	Site        string   `json:"site"`
-	User        string   `json:"user"`
-	ProjectType string   `json:"project_type"`
-	TestDriven  string   `json:"test_driven"`
-	GitInit     bool     `json:"git_init"`
-	Packages    []string `json:"packages"`
-}
-
-func promptInitInputs(cmd *cobra.Command, runner prompt.Runner) (initPrompt, error) {
-	moduleName, err := runner.Input(cmd, prompt.Input{
-		Title:       "Module name",
-		Placeholder: "go-toolkit",
-		Validate: func(value string) error {
-			_, err := validation.RequiredString(value, "module name")
-			return err
-		},
-	})
-	if err != nil {
-		return initPrompt{}, err
-	}
+	ModulePath  string         `json:"module_path"`
+	Site        string         `json:"site"`
+	User        string         `json:"user"`
+	ProjectType string         `json:"project_type"`
+	TestDriven  string         `json:"test_driven"`
+	GitInit     bool           `json:"git_init"`
+	Packages    []string       `json:"packages"`
+	Answers     map[string]any `json:"answers,omitempty"`
+	Conflicts   []initConflict `json:"conflicts,omitempty"`
+}
 
-	promptValues := initPrompt{
-		ModuleName: moduleName,
-		Used:       true,
-	}
+type initPlan struct {
+	ModulePath    string             `json:"module_path"`
+	Site          string             `json:"site"`
+	User          string             `json:"user"`
+	ProjectType   string             `json:"project_type"`
+	GitInit       bool               `json:"git_init"`
+	Packages      []string           `json:"packages"`
+	Answers       map[string]any     `json:"answers,omitempty"`
+	Files         []initPlanFile     `json:"files"`
+	Commands      []initPlanCommand  `json:"commands"`
+	ConfigChanges []initConfigChange `json:"config_changes"`
+}
 
-	userName, err := runner.Input(cmd, prompt.Input{
-		Title:       "Username",
-		Description: "Optional; leave blank to keep current config.",
-		Placeholder: "lou",
-	})
-	if err != nil {
-		if errors.Is(err, huh.ErrUserAborted) {
-			return promptValues, nil
-		}
-		return initPrompt{}, err
-	}
+type initPlanFile struct {
+	Path   string `json:"path"`
+	Action string `json:"action"`
+}
 
-	promptValues.UserName = strings.TrimSpace(userName)
+type initPlanCommand struct {
+	Dir     string `json:"dir,omitempty"`
+	Command string `json:"command"`
+}
 
-	providerChoice, err := runner.Select(cmd, prompt.Select{
-		Title:   "Provider",
-		Options: buildProviderOptions(),
-	})
-	if err != nil {
-		if errors.Is(err, huh.ErrUserAborted) {
-			return promptValues, nil
-		}
-		return initPrompt{}, err
+type initConfigChange struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+type initConflict struct {
+	Path   string `json:"path"`
+	Action string `json:"action"`
+}
+
+const (
+	initFieldModule     = "module"
+	initFieldTemplate   = "template"
+	initFieldUser       = "user"
+	initFieldProvider   = "provider"
+	initFieldCustomSite = "custom_site"
+	initFieldTests      = "tests"
+	initFieldGit        = "git"
+	initFieldPackages   = "packages"
+)
+
+func buildInitForm(values config.Values, templateNames []string) prompt.Form {
+	templateOptions := buildTemplateOptions(templateNames)
+	defaultTemplate := templateTypeAPI
+	if !lo.Contains(templateNames, defaultTemplate) && len(templateOptions) > 0 {
+		defaultTemplate = templateOptions[0].Value
 	}
 
-	if providerChoice == providerSkipRemaining {
-		return promptValues, nil
+	defaultProvider := config.ResolveSite("", values)
+	defaultCustomSite := ""
+	if !config.IsKnownSite(defaultProvider) {
+		defaultCustomSite = defaultProvider
+		defaultProvider = providerCustom
 	}
 
-	if providerChoice == providerCustom {
-		customSite, err := runner.Input(cmd, prompt.Input{
-			Title:       "Custom provider",
-			Placeholder: "github.com",
-			Validate: func(value string) error {
-				trimmed, err := validation.RequiredString(value, "provider")
-				if err != nil {
-					return err
-				}
-				return cmdutil.ValidateSite(trimmed, true)
+	return prompt.Form{
+		Pages: []prompt.Page{
+			{
+				Title: "Project",
+				Fields: []prompt.Field{
+					{
+						Key:         initFieldModule,
+						Kind:        prompt.FieldInput,
+						Title:       "Module name",
+						Placeholder: "go-toolkit",
+						Validate: func(value string) error {
+							_, err := validation.RequiredString(value, "module name")
+							return err
+						},
+					},
+					{
+						Key:     initFieldTemplate,
+						Kind:    prompt.FieldSelect,
+						Title:   "Template",
+						Options: templateOptions,
+						Default: defaultTemplate,
+					},
+				},
+			},
+			{
+				Title:       "Publishing",
+				Description: "Changes here are saved to your config.",
+				Fields: []prompt.Field{
+					{
+						Key:         initFieldUser,
+						Kind:        prompt.FieldInput,
+						Title:       "Username",
+						Description: "Optional; leave blank to keep current config.",
+						Placeholder: "lou",
+						Default:     values.User,
+					},
+					{
+						Key:     initFieldProvider,
+						Kind:    prompt.FieldSelect,
+						Title:   "Provider",
+						Options: buildSiteOptions(),
+						Default: defaultProvider,
+					},
+				},
+			},
+			{
+				Title: "Custom provider",
+				Fields: []prompt.Field{
+					{
+						Key:         initFieldCustomSite,
+						Kind:        prompt.FieldInput,
+						Title:       "Custom provider",
+						Placeholder: "github.com",
+						Default:     defaultCustomSite,
+						Validate: func(value string) error {
+							trimmed, err := validation.RequiredString(value, "provider")
+							if err != nil {
+								return err
+							}
+							return cmdutil.ValidateSite(trimmed, true)
+						},
+					},
+				},
+				Hide: func(answers prompt.Answers) bool {
+					return answers.String(initFieldProvider) != providerCustom
+				},
+			},
+			{
+				Title: "Scaffold",
+				Fields: []prompt.Field{
+					{
+						Key:       initFieldTests,
+						Kind:      prompt.FieldConfirm,
+						Title:     "Test driven",
+						Confirmed: values.Scaffold.WriteTests,
+					},
+					{
+						Key:       initFieldGit,
+						Kind:      prompt.FieldConfirm,
+						Title:     "Use git init",
+						Confirmed: config.ResolveInitGit(values),
+					},
+					{
+						Key:         initFieldPackages,
+						Kind:        prompt.FieldText,
+						Title:       "Packages to install",
+						Description: "Optional; one username/package or username/package/vN entry per line, or leave blank to skip.",
+						Placeholder: "samber/lo\nonsi/ginkgo/v2",
+						Validate: func(value string) error {
+							_, err := validation.ParseShortPackageList(value, "packages to install")
+							return err
+						},
+					},
+				},
 			},
-		})
-		if err != nil {
-			if errors.Is(err, huh.ErrUserAborted) {
-				return promptValues, nil
-			}
-			return initPrompt{}, err
-		}
-		promptValues.ProviderSite = strings.TrimSpace(customSite)
-	} else if providerChoice != providerSkip {
-		promptValues.ProviderSite = providerChoice
-	}
-
-	projectType, err := runner.Select(cmd, prompt.Select{
-		Title: "Template",
-		Options: []prompt.Option{
-			{Label: "API", Value: templateTypeAPI},
-			{Label: "CLI", Value: templateTypeCLI},
-			{Label: "Lib", Value: templateTypeLib},
-			{Label: "Skip", Value: templateTypeSkip},
-			{Label: "Skip remaining", Value: templateTypeSkipRemaining},
 		},
-	})
-	if err != nil {
-		if errors.Is(err, huh.ErrUserAborted) {
-			return promptValues, nil
-		}
-		return initPrompt{}, err
+		Summary: func(answers prompt.Answers) string {
+			return strings.Join([]string{
+				"Module: " + answers.String(initFieldModule),
+				"Template: " + answers.String(initFieldTemplate),
+				"User: " + lo.CoalesceOrEmpty(strings.TrimSpace(answers.String(initFieldUser)), "(config)"),
+				"Site: " + resolveInitFormSite(answers),
+				"Tests: " + lo.Ternary(answers.Bool(initFieldTests), "yes", "no"),
+				"Git: " + lo.Ternary(answers.Bool(initFieldGit), "yes", "no"),
+				"Packages: " + lo.CoalesceOrEmpty(strings.Join(strings.Fields(answers.String(initFieldPackages)), ", "), "none"),
+			}, "\n")
+		},
+		Confirm: "Create project?",
 	}
+}
 
-	if projectType == templateTypeSkipRemaining {
-		return promptValues, nil
+func resolveInitFormSite(answers prompt.Answers) string {
+	if answers.String(initFieldProvider) == providerCustom {
+		return strings.TrimSpace(answers.String(initFieldCustomSite))
 	}
 
-	if projectType != templateTypeSkip {
-		promptValues.TemplateType = projectType
-	}
+	return answers.String(initFieldProvider)
+}
 
-	testChoice, err := runner.Select(cmd, prompt.Select{
-		Title: "Test driven",
-		Options: []prompt.Option{
-			{Label: "Yes", Value: testChoiceYes},
-			{Label: "No", Value: testChoiceNo},
-			{Label: "Skip", Value: testChoiceSkip},
-			{Label: "Skip remaining", Value: testChoiceSkipRemaining},
-		},
-	})
+func promptInitInputs(cmd *cobra.Command, runner prompt.Runner, values config.Values, templateNames []string) (initPrompt, error) {
+	answers, err := runner.Form(cmd, buildInitForm(values, templateNames))
 	if err != nil {
-		if errors.Is(err, huh.ErrUserAborted) {
-			return promptValues, nil
-		}
 		return initPrompt{}, err
 	}
 
-	if testChoice == testChoiceSkipRemaining {
-		promptValues.TestDrivenChoice = testChoice
-		return promptValues, nil
-	}
-
-	promptValues.TestDrivenChoice = testChoice
-
-	gitChoice, err := runner.Select(cmd, prompt.Select{
-		Title: "Use git init",
-		Options: []prompt.Option{
-			{Label: "Yes", Value: gitChoiceYes},
-			{Label: "No", Value: gitChoiceNo},
-			{Label: "Skip", Value: gitChoiceSkip},
-			{Label: "Skip remaining", Value: gitChoiceSkipRemaining},
-		},
-	})
+	packages, err := validation.ParseShortPackageList(answers.String(initFieldPackages), "packages to install")
 	if err != nil {
-		if errors.Is(err, huh.ErrUserAborted) {
-			return promptValues, nil
-		}
 		return initPrompt{}, err
 	}
 
-	if gitChoice == gitChoiceSkipRemaining {
-		promptValues.GitChoice = gitChoice
-		return promptValues, nil
+	promptValues := initPrompt{
+		ModuleName:       strings.TrimSpace(answers.String(initFieldModule)),
+		TemplateType:     answers.String(initFieldTemplate),
+		TestDrivenChoice: lo.Ternary(answers.Bool(initFieldTests), testChoiceYes, testChoiceNo),
+		GitChoice:        lo.Ternary(answers.Bool(initFieldGit), gitChoiceYes, gitChoiceNo),
+		Packages:         packages,
+		Used:             true,
+	}
+	if userName := strings.TrimSpace(answers.String(initFieldUser)); userName != values.User {
+		promptValues.UserName = userName
+	}
+	if site := resolveInitFormSite(answers); site != values.Site {
+		promptValues.ProviderSite = site
 	}
 
-	promptValues.GitChoice = gitChoice
+	return promptValues, nil
+}
 
-	packageInput, err := runner.Input(cmd, prompt.Input{
-		Title:       "Packages to install",
-		Description: "Optional; use space-separated username/package or username/package/vN entries, or leave blank to skip.",
-		Placeholder: "samber/lo onsi/ginkgo/v2",
-		Validate: func(value string) error {
-			_, err := validation.ParseShortPackageList(value, "packages to install")
-			return err
-		},
-	})
-	if err != nil {
-		if errors.Is(err, huh.ErrUserAborted) {
-			return promptValues, nil
-		}
-		return initPrompt{}, err
+func buildTemplateOptions(templateNames []string) []prompt.Option {
+	builtinLabels := map[string]string{
+		templateTypeAPI: "API",
+		templateTypeCLI: "CLI",
+		templateTypeLib: "Lib",
 	}
 
-	promptValues.Packages, err = validation.ParseShortPackageList(packageInput, "packages to install")
-	if err != nil {
-		return initPrompt{}, err
-	}
-	return promptValues, nil
+	return lo.Map(templateNames, func(name string, _ int) prompt.Option {
+		if label, ok := builtinLabels[name]; ok {
+			return prompt.Option{Label: label, Value: name}
+		}
+		return prompt.Option{Label: name, Value: name}
+	})
 }
 
 func promptInitConfigInputs(cmd *cobra.Command, runner prompt.Runner, values config.Values, flagSite string, flagUser string, flagGit string) (initPrompt, error) {
@@ -549,25 +966,22 @@ func promptInitConfigInputs(cmd *cobra.Command, runner prompt.Runner, values con
 	}
 
 	if flagGit == "" && values.Scaffold.InitGit == nil {
-		gitChoice, err := runner.Select(cmd, prompt.Select{
-			Title: "Use git init",
-			Options: []prompt.Option{
-				{Label: "Yes", Value: gitChoiceYes},
-				{Label: "No", Value: gitChoiceNo},
-			},
+		useGit, err := runner.Confirm(cmd, prompt.Confirm{
+			Title:   "Use git init",
+			Default: true,
 		})
 		if err != nil {
 			return initPrompt{}, err
 		}
 
-		promptValues.GitChoice = gitChoice
+		promptValues.GitChoice = lo.Ternary(useGit, gitChoiceYes, gitChoiceNo)
 		promptValues.Used = true
 	}
 
 	return promptValues, nil
 }
 
-func writeInitSummary(cmd *cobra.Command, modulePath string, site string, user string, template string, shouldInitGit bool, prompt initPrompt) error {
+func writeInitSummary(cmd *cobra.Command, modulePath string, site string, user string, template string, shouldInitGit bool, prompt initPrompt, conflicts []project.ConflictResolution) error {
 	testDriven := prompt.TestDrivenChoice
 	if testDriven == "" {
 		testDriven = testChoiceSkip
@@ -586,6 +1000,10 @@ func writeInitSummary(cmd *cobra.Command, modulePath string, site string, user s
 		TestDriven:  testDriven,
 		GitInit:     shouldInitGit,
 		Packages:    packages,
+		Answers:     prompt.TemplateAnswers,
+		Conflicts: lo.Map(conflicts, func(conflict project.ConflictResolution, _ int) initConflict {
+			return initConflict{Path: conflict.Path, Action: conflict.Action}
+		}),
 	}
 
 	return cmdutil.WritePrettyJSON(cmd.OutOrStdout(), summary)
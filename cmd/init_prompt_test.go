@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"github.com/charmbracelet/huh"
+	"github.com/louiss0/go-toolkit/internal/modindex/config"
+	"github.com/louiss0/go-toolkit/internal/project"
+	"github.com/louiss0/go-toolkit/internal/prompt"
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -10,161 +13,173 @@ import (
 var InitPrompt = Describe("Init prompt", func() {
 	assert := assert.New(GinkgoT())
 
-	It("returns aborted when the module prompt is canceled", func() {
+	It("returns aborted when the form is canceled", func() {
 		mock := newPromptMock(promptStep{
-			kind: promptStepInput,
+			kind: promptStepForm,
 			err:  huh.ErrUserAborted,
 		})
 
-		_, err := promptInitInputs(&cobra.Command{}, mock)
+		_, err := promptInitInputs(&cobra.Command{}, mock, config.Values{}, project.TemplateValues())
 
 		assert.ErrorIs(err, huh.ErrUserAborted)
 	})
 
-	It("returns module name when canceled after the first step", func() {
-		mock := newPromptMock(
-			promptStep{kind: promptStepInput, value: "toolkit"},
-			promptStep{kind: promptStepInput, err: huh.ErrUserAborted},
-		)
+	It("treats a declined confirmation as an abort", func() {
+		mock := newPromptMock(promptStep{
+			kind: promptStepForm,
+			err:  prompt.ErrFormDeclined,
+		})
 
-		values, err := promptInitInputs(&cobra.Command{}, mock)
+		_, err := promptInitInputs(&cobra.Command{}, mock, config.Values{}, project.TemplateValues())
 
-		assert.NoError(err)
-		assert.Equal("toolkit", values.ModuleName)
-		assert.True(values.Used)
+		assert.ErrorIs(err, huh.ErrUserAborted)
 	})
 
-	It("supports provider skip remaining", func() {
-		mock := newPromptMock(
-			promptStep{kind: promptStepInput, value: "toolkit"},
-			promptStep{kind: promptStepInput, value: ""},
-			promptStep{kind: promptStepSelect, value: providerSkipRemaining},
-		)
+	It("fills unanswered fields from config", func() {
+		initGit := false
+		values := config.Values{
+			User: "lou",
+			Site: "gitlab.com",
+			Scaffold: config.ScaffoldConfig{
+				WriteTests: true,
+				InitGit:    &initGit,
+			},
+		}
+		mock := newPromptMock(promptStep{
+			kind:    promptStepForm,
+			answers: prompt.Answers{initFieldModule: "toolkit"},
+		})
 
-		values, err := promptInitInputs(&cobra.Command{}, mock)
+		promptValues, err := promptInitInputs(&cobra.Command{}, mock, values, project.TemplateValues())
 
 		assert.NoError(err)
-		assert.Equal("toolkit", values.ModuleName)
-		assert.Equal("", values.ProviderSite)
+		assert.Equal("toolkit", promptValues.ModuleName)
+		assert.Equal(templateTypeAPI, promptValues.TemplateType)
+		assert.Equal("", promptValues.UserName)
+		assert.Equal("", promptValues.ProviderSite)
+		assert.Equal(testChoiceYes, promptValues.TestDrivenChoice)
+		assert.Equal(gitChoiceNo, promptValues.GitChoice)
+		assert.Nil(promptValues.Packages)
+		assert.True(promptValues.Used)
 	})
 
-	It("handles custom provider cancellation", func() {
-		mock := newPromptMock(
-			promptStep{kind: promptStepInput, value: "toolkit"},
-			promptStep{kind: promptStepInput, value: "lou"},
-			promptStep{kind: promptStepSelect, value: providerCustom},
-			promptStep{kind: promptStepInput, err: huh.ErrUserAborted},
-		)
+	It("returns every answer from the form", func() {
+		mock := newPromptMock(promptStep{
+			kind: promptStepForm,
+			answers: prompt.Answers{
+				initFieldModule:   "toolkit",
+				initFieldTemplate: templateTypeLib,
+				initFieldUser:     " lou ",
+				initFieldProvider: "codeberg.org",
+				initFieldTests:    false,
+				initFieldGit:      true,
+				initFieldPackages: "samber/lo\nonsi/ginkgo/v2\n",
+			},
+		})
 
-		values, err := promptInitInputs(&cobra.Command{}, mock)
+		promptValues, err := promptInitInputs(&cobra.Command{}, mock, config.Values{}, project.TemplateValues())
 
 		assert.NoError(err)
-		assert.Equal("toolkit", values.ModuleName)
-		assert.Equal("lou", values.UserName)
-		assert.Equal("", values.ProviderSite)
+		assert.Equal(templateTypeLib, promptValues.TemplateType)
+		assert.Equal("lou", promptValues.UserName)
+		assert.Equal("codeberg.org", promptValues.ProviderSite)
+		assert.Equal(testChoiceNo, promptValues.TestDrivenChoice)
+		assert.Equal(gitChoiceYes, promptValues.GitChoice)
+		assert.Equal([]string{"samber/lo", "onsi/ginkgo/v2"}, promptValues.Packages)
 	})
 
-	It("accepts skip values for later steps", func() {
-		mock := newPromptMock(
-			promptStep{kind: promptStepInput, value: "toolkit"},
-			promptStep{kind: promptStepInput, value: ""},
-			promptStep{kind: promptStepSelect, value: providerSkip},
-			promptStep{kind: promptStepSelect, value: templateTypeLib},
-			promptStep{kind: promptStepSelect, value: testChoiceNo},
-			promptStep{kind: promptStepSelect, value: gitChoiceNo},
-			promptStep{kind: promptStepInput, value: "samber/lo"},
-		)
+	It("uses the custom provider page when custom is chosen", func() {
+		mock := newPromptMock(promptStep{
+			kind: promptStepForm,
+			answers: prompt.Answers{
+				initFieldModule:     "toolkit",
+				initFieldProvider:   providerCustom,
+				initFieldCustomSite: "git.example.com",
+			},
+		})
 
-		values, err := promptInitInputs(&cobra.Command{}, mock)
+		promptValues, err := promptInitInputs(&cobra.Command{}, mock, config.Values{}, project.TemplateValues())
 
 		assert.NoError(err)
-		assert.Equal(templateTypeLib, values.TemplateType)
-		assert.Equal(testChoiceNo, values.TestDrivenChoice)
-		assert.Equal(gitChoiceNo, values.GitChoice)
-		assert.Equal([]string{"samber/lo"}, values.Packages)
+		assert.Equal("git.example.com", promptValues.ProviderSite)
 	})
 
-	It("stops after test skip remaining", func() {
-		mock := newPromptMock(
-			promptStep{kind: promptStepInput, value: "toolkit"},
-			promptStep{kind: promptStepInput, value: "lou"},
-			promptStep{kind: promptStepSelect, value: "github.com"},
-			promptStep{kind: promptStepSelect, value: templateTypeAPI},
-			promptStep{kind: promptStepSelect, value: testChoiceSkipRemaining},
-		)
+	It("skips the custom provider page for known sites", func() {
+		form := buildInitForm(config.Values{}, project.TemplateValues())
 
-		values, err := promptInitInputs(&cobra.Command{}, mock)
+		answers, err := form.Merge(prompt.Answers{initFieldModule: "toolkit"})
 
 		assert.NoError(err)
-		assert.Equal(testChoiceSkipRemaining, values.TestDrivenChoice)
-		assert.Equal("", values.GitChoice)
-		assert.Nil(values.Packages)
+		assert.Equal("github.com", answers.String(initFieldProvider))
+		assert.Equal("", answers.String(initFieldCustomSite))
 	})
 
-	It("stops after git skip remaining", func() {
-		mock := newPromptMock(
-			promptStep{kind: promptStepInput, value: "toolkit"},
-			promptStep{kind: promptStepInput, value: "lou"},
-			promptStep{kind: promptStepSelect, value: "github.com"},
-			promptStep{kind: promptStepSelect, value: templateTypeAPI},
-			promptStep{kind: promptStepSelect, value: testChoiceYes},
-			promptStep{kind: promptStepSelect, value: gitChoiceSkipRemaining},
-		)
+	It("defaults to the custom provider page for custom config sites", func() {
+		form := buildInitForm(config.Values{Site: "git.example.com"}, project.TemplateValues())
 
-		values, err := promptInitInputs(&cobra.Command{}, mock)
+		answers, err := form.Merge(prompt.Answers{initFieldModule: "toolkit"})
 
 		assert.NoError(err)
-		assert.Equal(gitChoiceSkipRemaining, values.GitChoice)
+		assert.Equal(providerCustom, answers.String(initFieldProvider))
+		assert.Equal("git.example.com", resolveInitFormSite(answers))
 	})
 
-	It("allows canceling packages input", func() {
-		mock := newPromptMock(
-			promptStep{kind: promptStepInput, value: "toolkit"},
-			promptStep{kind: promptStepInput, value: "lou"},
-			promptStep{kind: promptStepSelect, value: "github.com"},
-			promptStep{kind: promptStepSelect, value: templateTypeAPI},
-			promptStep{kind: promptStepSelect, value: testChoiceYes},
-			promptStep{kind: promptStepSelect, value: gitChoiceYes},
-			promptStep{kind: promptStepInput, err: huh.ErrUserAborted},
-		)
+	It("requires a module name", func() {
+		mock := newPromptMock(promptStep{
+			kind:    promptStepForm,
+			answers: prompt.Answers{initFieldModule: "  "},
+		})
 
-		values, err := promptInitInputs(&cobra.Command{}, mock)
+		_, err := promptInitInputs(&cobra.Command{}, mock, config.Values{}, project.TemplateValues())
 
-		assert.NoError(err)
-		assert.Nil(values.Packages)
+		assert.Error(err)
+		assert.Contains(err.Error(), "module name")
 	})
 
-	It("allows skipping package installs with a blank value", func() {
-		mock := newPromptMock(
-			promptStep{kind: promptStepInput, value: "toolkit"},
-			promptStep{kind: promptStepInput, value: "lou"},
-			promptStep{kind: promptStepSelect, value: "github.com"},
-			promptStep{kind: promptStepSelect, value: templateTypeAPI},
-			promptStep{kind: promptStepSelect, value: testChoiceYes},
-			promptStep{kind: promptStepSelect, value: gitChoiceYes},
-			promptStep{kind: promptStepInput, value: "   "},
-		)
+	It("rejects templates that are not offered", func() {
+		mock := newPromptMock(promptStep{
+			kind: promptStepForm,
+			answers: prompt.Answers{
+				initFieldModule:   "toolkit",
+				initFieldTemplate: "grpc",
+			},
+		})
 
-		values, err := promptInitInputs(&cobra.Command{}, mock)
+		_, err := promptInitInputs(&cobra.Command{}, mock, config.Values{}, project.TemplateValues())
 
-		assert.NoError(err)
-		assert.Nil(values.Packages)
+		assert.Error(err)
+		assert.Contains(err.Error(), `template does not offer "grpc"`)
 	})
 
 	It("rejects invalid package install syntax", func() {
-		mock := newPromptMock(
-			promptStep{kind: promptStepInput, value: "toolkit"},
-			promptStep{kind: promptStepInput, value: "lou"},
-			promptStep{kind: promptStepSelect, value: "github.com"},
-			promptStep{kind: promptStepSelect, value: templateTypeAPI},
-			promptStep{kind: promptStepSelect, value: testChoiceYes},
-			promptStep{kind: promptStepSelect, value: gitChoiceYes},
-			promptStep{kind: promptStepInput, value: "github.com/spf13/cobra"},
-		)
-
-		_, err := promptInitInputs(&cobra.Command{}, mock)
+		mock := newPromptMock(promptStep{
+			kind: promptStepForm,
+			answers: prompt.Answers{
+				initFieldModule:   "toolkit",
+				initFieldPackages: "github.com/spf13/cobra",
+			},
+		})
+
+		_, err := promptInitInputs(&cobra.Command{}, mock, config.Values{}, project.TemplateValues())
 
 		assert.Error(err)
 		assert.Contains(err.Error(), "packages to install must use space-separated username/package or username/package/vN entries")
 	})
+
+	It("summarizes the answers before confirmation", func() {
+		form := buildInitForm(config.Values{}, project.TemplateValues())
+
+		answers, err := form.Merge(prompt.Answers{
+			initFieldModule:   "toolkit",
+			initFieldPackages: "samber/lo\nonsi/ginkgo/v2",
+		})
+		assert.NoError(err)
+
+		summary := form.Summary(answers)
+
+		assert.Contains(summary, "Module: toolkit")
+		assert.Contains(summary, "Site: github.com")
+		assert.Contains(summary, "Packages: samber/lo, onsi/ginkgo/v2")
+		assert.Equal("Create project?", form.Confirm)
+	})
 })
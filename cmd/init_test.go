@@ -1,14 +1,18 @@
 package cmd_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 
 	"github.com/louiss0/go-toolkit/cmd"
 	"github.com/louiss0/go-toolkit/internal/modindex/config"
+	"github.com/louiss0/go-toolkit/internal/prompt"
 	"github.com/louiss0/go-toolkit/internal/testhelpers"
 	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -62,6 +66,7 @@ var Init = Describe("init command", func() {
 		content, err := os.ReadFile(filepath.Join(targetPath, "cmd", "main.go"))
 		assert.NoError(err)
 		assert.Contains(string(content), "package main")
+		assert.Contains(string(content), "\"github.com/lou/toolkit/internal/app\"")
 	})
 
 	It("prompts for init details when no args are provided", func() {
@@ -78,13 +83,15 @@ var Init = Describe("init command", func() {
 		})
 
 		promptRunner := testhelpers.NewPromptRunnerMock(
-			testhelpers.PromptStep{Kind: testhelpers.PromptStepInput, Value: "toolkit"},
-			testhelpers.PromptStep{Kind: testhelpers.PromptStepInput, Value: "lou"},
-			testhelpers.PromptStep{Kind: testhelpers.PromptStepSelect, Value: "github.com"},
-			testhelpers.PromptStep{Kind: testhelpers.PromptStepSelect, Value: "lib"},
-			testhelpers.PromptStep{Kind: testhelpers.PromptStepSelect, Value: "yes"},
-			testhelpers.PromptStep{Kind: testhelpers.PromptStepSelect, Value: "no"},
-			testhelpers.PromptStep{Kind: testhelpers.PromptStepInput, Value: "samber/lo"},
+			testhelpers.PromptStep{Kind: testhelpers.PromptStepForm, Answers: prompt.Answers{
+				"module":   "toolkit",
+				"template": "lib",
+				"user":     "lou",
+				"provider": "github.com",
+				"tests":    true,
+				"git":      false,
+				"packages": "samber/lo",
+			}},
 		)
 
 		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
@@ -135,6 +142,115 @@ var Init = Describe("init command", func() {
 		assert.Equal("lib", summary["project_type"])
 	})
 
+	It("runs init without a terminal from an answers file", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+		answersPath := filepath.Join(tempDir, "answers.toml")
+		workingDir, err := os.Getwd()
+		assert.NoError(err)
+
+		err = os.WriteFile(answersPath, []byte("module = \"toolkit\"\ntemplate = \"lib\"\nusername = \"lou\"\nprovider = \"gitlab.com\"\ntests = false\ngit = false\npackages = [\"samber/lo\"]\n"), 0o644)
+		assert.NoError(err)
+
+		err = os.Chdir(tempDir)
+		assert.NoError(err)
+		DeferCleanup(func() {
+			_ = os.Chdir(workingDir)
+		})
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		runner.On("Run", mock.Anything, "go", []string{"-C", ".", "mod", "init", "gitlab.com/lou/toolkit"}).Return(nil).Once()
+		runner.On("Run", mock.Anything, "go", []string{"-C", ".", "get", "gitlab.com/samber/lo"}).Return(nil).Once()
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "init", "--answers", answersPath, "--strict-answers")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Equal("lou", values.User)
+		assert.Equal("gitlab.com", values.Site)
+	})
+
+	It("fails in strict answer mode when a prompt has no answer", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		GinkgoT().Setenv("GTK_ANSWER_USERNAME", "lou")
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err := testhelpers.ExecuteCmd(rootCmd, "config", "init", "--strict-answers")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), `no answer for prompt "Provider"`)
+		runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("falls back to config defaults with --no-input", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+		workingDir, err := os.Getwd()
+		assert.NoError(err)
+
+		err = os.WriteFile(configPath, []byte("user = \"lou\"\n"), 0o644)
+		assert.NoError(err)
+
+		err = os.Chdir(tempDir)
+		assert.NoError(err)
+		DeferCleanup(func() {
+			_ = os.Chdir(workingDir)
+		})
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		runner.On("Run", mock.Anything, "go", []string{"-C", "toolkit", "mod", "init", "github.com/lou/toolkit"}).Return(nil).Once()
+		runner.On("Run", mock.Anything, "git", []string{"-C", "toolkit", "init"}).Return(nil).Once()
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "init", "toolkit", "--no-input")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Equal("", values.Site)
+		assert.Nil(values.Scaffold.InitGit)
+	})
+
+	It("asks for a folder when init has no input", func() {
+		runner := &testhelpers.RunnerMock{}
+		configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err := testhelpers.ExecuteCmd(rootCmd, "init", "--no-input")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "missing folder; pass a folder argument")
+	})
+
 	It("installs packages from flags and presets", func() {
 		runner := &testhelpers.RunnerMock{}
 		tempDir := GinkgoT().TempDir()
@@ -305,7 +421,7 @@ var Init = Describe("init command", func() {
 
 		promptRunner := testhelpers.NewPromptRunnerMock(
 			testhelpers.PromptStep{Kind: testhelpers.PromptStepInput, Value: "lou"},
-			testhelpers.PromptStep{Kind: testhelpers.PromptStepSelect, Value: "yes"},
+			testhelpers.PromptStep{Kind: testhelpers.PromptStepConfirm, Confirmed: true},
 		)
 
 		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
@@ -334,4 +450,477 @@ var Init = Describe("init command", func() {
 		_, err = os.Stat(filepath.Join(targetPath, ".gitignore"))
 		assert.NoError(err)
 	})
+
+	It("prints a plan without touching disk on --dry-run", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		target := "toolkit"
+		targetPath := filepath.Join(tempDir, target)
+		configPath := filepath.Join(tempDir, "config.toml")
+		workingDir, err := os.Getwd()
+		assert.NoError(err)
+
+		configContent := "site = \"gitlab.com\"\n"
+		err = os.WriteFile(configPath, []byte(configContent), 0o644)
+		assert.NoError(err)
+
+		err = os.Chdir(tempDir)
+		assert.NoError(err)
+		DeferCleanup(func() {
+			_ = os.Chdir(workingDir)
+		})
+
+		promptRunner := testhelpers.NewPromptRunnerMock(
+			testhelpers.PromptStep{Kind: testhelpers.PromptStepInput, Value: "lou"},
+			testhelpers.PromptStep{Kind: testhelpers.PromptStepConfirm, Confirmed: true},
+		)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: promptRunner,
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "init", "toolkit", "--template", "lib", "--package", "samber/lo", "--dry-run")
+
+		assert.NoError(err)
+		runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything)
+
+		_, err = os.Stat(targetPath)
+		assert.True(os.IsNotExist(err))
+		content, err := os.ReadFile(configPath)
+		assert.NoError(err)
+		assert.Equal(configContent, string(content))
+
+		type planEntry struct {
+			Path    string `json:"path"`
+			Action  string `json:"action"`
+			Command string `json:"command"`
+			Key     string `json:"key"`
+			Value   any    `json:"value"`
+		}
+		var plan struct {
+			ModulePath    string      `json:"module_path"`
+			Files         []planEntry `json:"files"`
+			Commands      []planEntry `json:"commands"`
+			ConfigChanges []planEntry `json:"config_changes"`
+		}
+		err = json.Unmarshal([]byte(output), &plan)
+		assert.NoError(err)
+
+		assert.Equal("gitlab.com/lou/toolkit", plan.ModulePath)
+		assert.Contains(plan.Files, planEntry{Path: ".gitignore", Action: "create"})
+		assert.Contains(plan.Files, planEntry{Path: "external/external.go", Action: "create"})
+
+		commands := []string{}
+		for _, command := range plan.Commands {
+			commands = append(commands, command.Command)
+		}
+		assert.Equal([]string{
+			"go -C toolkit mod init gitlab.com/lou/toolkit",
+			"go -C toolkit get gitlab.com/samber/lo",
+			"git -C toolkit init",
+		}, commands)
+		assert.Len(plan.ConfigChanges, 2)
+		assert.Equal("user", plan.ConfigChanges[0].Key)
+		assert.Equal("lou", plan.ConfigChanges[0].Value)
+		assert.Equal("scaffold.init_git", plan.ConfigChanges[1].Key)
+		assert.Equal(true, plan.ConfigChanges[1].Value)
+	})
+
+	It("adds the module to the nearest workspace", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := testhelpers.CanonicalPath(GinkgoT().TempDir())
+		configPath := filepath.Join(tempDir, "config.toml")
+		workingDir, err := os.Getwd()
+		assert.NoError(err)
+
+		err = os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\n[scaffold]\ninit_git = false\n"), 0o644)
+		assert.NoError(err)
+		err = os.WriteFile(filepath.Join(tempDir, "go.work"), []byte("go 1.24\n"), 0o644)
+		assert.NoError(err)
+
+		err = os.Chdir(tempDir)
+		assert.NoError(err)
+		DeferCleanup(func() {
+			_ = os.Chdir(workingDir)
+		})
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		runner.On("Run", mock.Anything, "go", []string{"-C", "toolkit", "mod", "init", "github.com/lou/toolkit"}).Return(nil).Once()
+		runner.On("Run", mock.Anything, "go", []string{"-C", tempDir, "work", "use", "./toolkit"}).Return(nil).Once()
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "init", "toolkit", "--workspace")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+	})
+
+	It("applies a user-defined template from the config", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		target := "worker"
+		targetPath := filepath.Join(tempDir, target)
+		configPath := filepath.Join(tempDir, "config.toml")
+		workingDir, err := os.Getwd()
+		assert.NoError(err)
+
+		templateDir := filepath.Join(tempDir, "templates", "worker")
+		err = os.MkdirAll(filepath.Join(templateDir, "cmd"), 0o755)
+		assert.NoError(err)
+		err = os.MkdirAll(filepath.Join(templateDir, ".git"), 0o755)
+		assert.NoError(err)
+		err = os.WriteFile(filepath.Join(templateDir, "cmd", "main.go.tmpl"), []byte("package main\n\n// {{.ModulePath}}\nfunc main() {}\n"), 0o644)
+		assert.NoError(err)
+		err = os.WriteFile(filepath.Join(templateDir, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0o644)
+		assert.NoError(err)
+
+		err = os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\n[scaffold]\ninit_git = false\n[templates]\nworker = \"templates/worker\"\n"), 0o644)
+		assert.NoError(err)
+
+		err = os.Chdir(tempDir)
+		assert.NoError(err)
+		DeferCleanup(func() {
+			_ = os.Chdir(workingDir)
+		})
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		runner.On("Run", mock.Anything, "go", []string{"-C", target, "mod", "init", "github.com/lou/worker"}).Return(nil).Once()
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "init", "worker", "--template", "worker")
+
+		assert.NoError(err)
+
+		content, err := os.ReadFile(filepath.Join(targetPath, "cmd", "main.go"))
+		assert.NoError(err)
+		assert.Contains(string(content), "// github.com/lou/worker")
+		_, err = os.Stat(filepath.Join(targetPath, ".git"))
+		assert.Error(err)
+
+		var summary map[string]any
+		err = json.Unmarshal([]byte(output), &summary)
+		assert.NoError(err)
+		assert.Equal("worker", summary["project_type"])
+	})
+
+	It("rejects unknown templates before running commands", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := writeDefaultConfig(configPath)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "init", "toolkit", "--template", "worker")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "template must be one of: api, cli, lib")
+		runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("drives prompts, conditional files and hooks from a template manifest", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		target := "service"
+		targetPath := filepath.Join(tempDir, target)
+		configPath := filepath.Join(tempDir, "config.toml")
+		workingDir, err := os.Getwd()
+		assert.NoError(err)
+
+		templateDir := filepath.Join(tempDir, "templates", "grpc")
+		err = os.MkdirAll(filepath.Join(templateDir, "deploy"), 0o755)
+		assert.NoError(err)
+		err = os.WriteFile(filepath.Join(templateDir, "main.go.tmpl"), []byte("package main\n\n// port {{.Answers.port}}\nfunc main() {}\n"), 0o644)
+		assert.NoError(err)
+		err = os.WriteFile(filepath.Join(templateDir, "Dockerfile.tmpl"), []byte("FROM golang:{{.GoVersion}}\n"), 0o644)
+		assert.NoError(err)
+		err = os.WriteFile(filepath.Join(templateDir, "deploy", "chart.yaml"), []byte("name: service\n"), 0o644)
+		assert.NoError(err)
+		manifest := `description = "gRPC service"
+packages = ["github.com/samber/lo"]
+
+[[prompts]]
+key = "docker"
+title = "Include Dockerfile"
+type = "confirm"
+
+[[prompts]]
+key = "port"
+title = "Port"
+default = "9090"
+
+[[files]]
+path = "Dockerfile"
+when = "docker"
+
+[[files]]
+path = "deploy"
+when = "!docker"
+
+[[hooks]]
+command = "go"
+args = ["mod", "edit", "-module={{.ModulePath}}"]
+
+[[hooks]]
+command = "docker"
+args = ["build", "."]
+when = "docker"
+`
+		err = os.WriteFile(filepath.Join(templateDir, "gtk-template.toml"), []byte(manifest), 0o644)
+		assert.NoError(err)
+
+		err = os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\n[scaffold]\ninit_git = false\n[templates]\ngrpc = \"templates/grpc\"\n"), 0o644)
+		assert.NoError(err)
+
+		err = os.Chdir(tempDir)
+		assert.NoError(err)
+		DeferCleanup(func() {
+			_ = os.Chdir(workingDir)
+		})
+
+		promptRunner := testhelpers.NewPromptRunnerMock(
+			testhelpers.PromptStep{Kind: testhelpers.PromptStepConfirm, Confirmed: true},
+			testhelpers.PromptStep{Kind: testhelpers.PromptStepInput, Value: ""},
+		)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: promptRunner,
+			ConfigPath:   configPath,
+		})
+
+		runner.On("Run", mock.Anything, "go", []string{"-C", target, "mod", "init", "github.com/lou/service"}).Return(nil).Once()
+		runner.On("Run", mock.Anything, "go", []string{"-C", target, "get", "github.com/samber/lo"}).Return(nil).Once()
+		runner.On("RunInDir", mock.Anything, target, "go", []string{"mod", "edit", "-module=github.com/lou/service"}).Return(nil).Once()
+		runner.On("RunInDir", mock.Anything, target, "docker", []string{"build", "."}).Return(nil).Once()
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "init", "service", "--template", "grpc")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+
+		content, err := os.ReadFile(filepath.Join(targetPath, "main.go"))
+		assert.NoError(err)
+		assert.Contains(string(content), "// port 9090")
+		_, err = os.Stat(filepath.Join(targetPath, "Dockerfile"))
+		assert.NoError(err)
+		_, err = os.Stat(filepath.Join(targetPath, "deploy"))
+		assert.Error(err)
+		_, err = os.Stat(filepath.Join(targetPath, "gtk-template.toml"))
+		assert.Error(err)
+
+		var summary map[string]any
+		err = json.Unmarshal([]byte(output), &summary)
+		assert.NoError(err)
+		assert.Equal(map[string]any{"docker": true, "port": "9090"}, summary["answers"])
+	})
+
+	Describe("inside an existing folder", func() {
+		var runner *testhelpers.RunnerMock
+		var tempDir string
+		var targetPath string
+		var configPath string
+
+		BeforeEach(func() {
+			runner = &testhelpers.RunnerMock{}
+			tempDir = GinkgoT().TempDir()
+			targetPath = filepath.Join(tempDir, "toolkit")
+			configPath = filepath.Join(tempDir, "config.toml")
+			workingDir, err := os.Getwd()
+			assert.NoError(err)
+
+			err = os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\n[scaffold]\ninit_git = false\n"), 0o644)
+			assert.NoError(err)
+			err = os.MkdirAll(filepath.Join(targetPath, "cmd"), 0o755)
+			assert.NoError(err)
+			err = os.WriteFile(filepath.Join(targetPath, "cmd", "main.go"), []byte("package main\n"), 0o644)
+			assert.NoError(err)
+
+			err = os.Chdir(tempDir)
+			assert.NoError(err)
+			DeferCleanup(func() {
+				_ = os.Chdir(workingDir)
+			})
+
+			runner.On("Run", mock.Anything, "go", []string{"-C", "toolkit", "mod", "init", "github.com/lou/toolkit"}).Return(nil).Once()
+		})
+
+		executeInit := func(rootCmd *cobra.Command, args ...string) (string, string, error) {
+			stdout := new(bytes.Buffer)
+			stderr := new(bytes.Buffer)
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+			rootCmd.SetArgs(append([]string{"init"}, args...))
+
+			err := rootCmd.Execute()
+			return stdout.String(), stderr.String(), err
+		}
+
+		It("keeps existing files and reports the conflicts", func() {
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			output, stderr, err := executeInit(rootCmd, "toolkit")
+
+			assert.NoError(err)
+			assert.Equal("existing files differ from the api template:\n  cmd/main.go\n", stderr)
+			content, err := os.ReadFile(filepath.Join(targetPath, "cmd", "main.go"))
+			assert.NoError(err)
+			assert.Equal("package main\n", string(content))
+			assert.FileExists(filepath.Join(targetPath, "internal", "app", "app.go"))
+
+			var summary map[string]any
+			err = json.Unmarshal([]byte(output), &summary)
+			assert.NoError(err)
+			assert.Equal([]any{map[string]any{"path": "cmd/main.go", "action": "skip"}}, summary["conflicts"])
+		})
+
+		It("overwrites existing files with --force", func() {
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			_, _, err := executeInit(rootCmd, "toolkit", "--force")
+
+			assert.NoError(err)
+			content, err := os.ReadFile(filepath.Join(targetPath, "cmd", "main.go"))
+			assert.NoError(err)
+			assert.Contains(string(content), "github.com/lou/toolkit/internal/app")
+		})
+
+		It("asks how to resolve each conflict when prompting", func() {
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner: runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(
+					testhelpers.PromptStep{Kind: testhelpers.PromptStepSelect, Value: "new"},
+				),
+				ConfigPath: configPath,
+			})
+
+			_, stderr, err := executeInit(rootCmd, "toolkit", "--on-conflict", "prompt")
+
+			assert.NoError(err)
+			assert.Contains(stderr, "cmd/main.go")
+			content, err := os.ReadFile(filepath.Join(targetPath, "cmd", "main.go"))
+			assert.NoError(err)
+			assert.Equal("package main\n", string(content))
+			assert.FileExists(filepath.Join(targetPath, "cmd", "main.go.new"))
+		})
+	})
+
+	Describe("when a step fails", func() {
+		It("removes created files and restores the config", func() {
+			runner := &testhelpers.RunnerMock{}
+			tempDir := GinkgoT().TempDir()
+			targetPath := filepath.Join(tempDir, "toolkit")
+			configPath := filepath.Join(tempDir, "config.toml")
+			workingDir, err := os.Getwd()
+			assert.NoError(err)
+
+			configContent := "site = \"github.com\"\n"
+			err = os.WriteFile(configPath, []byte(configContent), 0o644)
+			assert.NoError(err)
+
+			err = os.Chdir(tempDir)
+			assert.NoError(err)
+			DeferCleanup(func() {
+				_ = os.Chdir(workingDir)
+			})
+
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner: runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(
+					testhelpers.PromptStep{Kind: testhelpers.PromptStepInput, Value: "lou"},
+					testhelpers.PromptStep{Kind: testhelpers.PromptStepConfirm, Confirmed: false},
+				),
+				ConfigPath: configPath,
+			})
+
+			runner.On("Run", mock.Anything, "go", []string{"-C", "toolkit", "mod", "init", "github.com/lou/toolkit"}).
+				Run(func(mock.Arguments) {
+					_ = os.WriteFile(filepath.Join(targetPath, "go.mod"), []byte("module github.com/lou/toolkit\n"), 0o644)
+				}).
+				Return(nil).Once()
+			runner.On("Run", mock.Anything, "go", []string{"-C", "toolkit", "get", "github.com/samber/lo"}).
+				Return(errors.New("go get failed")).Once()
+
+			_, err = testhelpers.ExecuteCmd(rootCmd, "init", "toolkit", "--package", "samber/lo")
+
+			assert.Error(err)
+			assert.Contains(err.Error(), "go get failed")
+			assert.Contains(err.Error(), "rolled back")
+			_, err = os.Stat(targetPath)
+			assert.True(os.IsNotExist(err))
+
+			content, err := os.ReadFile(configPath)
+			assert.NoError(err)
+			assert.Equal(configContent, string(content))
+		})
+
+		It("restores overwritten files in an existing folder", func() {
+			runner := &testhelpers.RunnerMock{}
+			tempDir := GinkgoT().TempDir()
+			targetPath := filepath.Join(tempDir, "toolkit")
+			mainPath := filepath.Join(targetPath, "cmd", "main.go")
+			configPath := filepath.Join(tempDir, "config.toml")
+			workingDir, err := os.Getwd()
+			assert.NoError(err)
+
+			err = os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\n[scaffold]\ninit_git = true\n"), 0o644)
+			assert.NoError(err)
+			err = os.MkdirAll(filepath.Dir(mainPath), 0o755)
+			assert.NoError(err)
+			err = os.WriteFile(mainPath, []byte("package main\n"), 0o644)
+			assert.NoError(err)
+
+			err = os.Chdir(tempDir)
+			assert.NoError(err)
+			DeferCleanup(func() {
+				_ = os.Chdir(workingDir)
+			})
+
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			runner.On("Run", mock.Anything, "go", []string{"-C", "toolkit", "mod", "init", "github.com/lou/toolkit"}).Return(nil).Once()
+			runner.On("Run", mock.Anything, "git", []string{"-C", "toolkit", "init"}).Return(errors.New("git init failed")).Once()
+
+			_, err = testhelpers.ExecuteCmd(rootCmd, "init", "toolkit", "--force")
+
+			assert.Error(err)
+			assert.Contains(err.Error(), "git init failed")
+
+			content, err := os.ReadFile(mainPath)
+			assert.NoError(err)
+			assert.Equal("package main\n", string(content))
+			_, err = os.Stat(filepath.Join(targetPath, "internal"))
+			assert.True(os.IsNotExist(err))
+			_, err = os.Stat(filepath.Join(targetPath, ".gitignore"))
+			assert.True(os.IsNotExist(err))
+		})
+	})
 })
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/louiss0/go-toolkit/internal/cmdutil"
+)
+
+type initUndo struct {
+	path    string
+	content []byte
+	mode    fs.FileMode
+	restore bool
+}
+
+type initTransaction struct {
+	undos     []initUndo
+	committed bool
+}
+
+func newInitTransaction() *initTransaction {
+	return &initTransaction{}
+}
+
+func (t *initTransaction) Track(path string) error {
+	cleanPath := filepath.Clean(path)
+	if t.covers(cleanPath) {
+		return nil
+	}
+
+	info, err := os.Stat(cleanPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.undos = append(t.undos, initUndo{path: topMissingPath(cleanPath)})
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return nil
+	}
+
+	content, err := os.ReadFile(cleanPath)
+	if err != nil {
+		return err
+	}
+
+	t.undos = append(t.undos, initUndo{
+		path:    cleanPath,
+		content: content,
+		mode:    info.Mode().Perm(),
+		restore: true,
+	})
+
+	return nil
+}
+
+func (t *initTransaction) Commit() {
+	t.committed = true
+}
+
+func (t *initTransaction) Rollback(cause error) error {
+	if t.committed || len(t.undos) == 0 {
+		return cause
+	}
+
+	undone := []string{}
+	rollbackErrors := []error{}
+	for _, undo := range slices.Backward(t.undos) {
+		if undo.restore {
+			if err := os.WriteFile(undo.path, undo.content, undo.mode); err != nil {
+				rollbackErrors = append(rollbackErrors, fmt.Errorf("restore %s: %w", undo.path, err))
+				continue
+			}
+			undone = append(undone, "restored "+undo.path)
+			continue
+		}
+
+		if err := os.RemoveAll(undo.path); err != nil {
+			rollbackErrors = append(rollbackErrors, fmt.Errorf("remove %s: %w", undo.path, err))
+			continue
+		}
+		undone = append(undone, "removed "+undo.path)
+	}
+	t.undos = nil
+
+	cmdutil.LogInfoIfProduction("init: rolled back %s", strings.Join(undone, ", "))
+	if cause == nil {
+		return errors.Join(rollbackErrors...)
+	}
+
+	return errors.Join(append([]error{
+		fmt.Errorf("%w; rolled back: %s", cause, strings.Join(undone, ", ")),
+	}, rollbackErrors...)...)
+}
+
+func (t *initTransaction) covers(path string) bool {
+	return slices.ContainsFunc(t.undos, func(undo initUndo) bool {
+		if undo.path == path {
+			return true
+		}
+		return !undo.restore && strings.HasPrefix(path, undo.path+string(filepath.Separator))
+	})
+}
+
+func topMissingPath(path string) string {
+	missing := path
+	for {
+		parent := filepath.Dir(missing)
+		if parent == missing {
+			return missing
+		}
+		if _, err := os.Stat(parent); err == nil {
+			return missing
+		}
+		missing = parent
+	}
+}
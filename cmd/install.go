@@ -11,7 +11,6 @@ import (
 	"github.com/louiss0/go-toolkit/internal/modindex/config"
 	"github.com/louiss0/go-toolkit/internal/prompt"
 	"github.com/louiss0/go-toolkit/internal/runner"
-	"github.com/louiss0/go-toolkit/validation"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
@@ -21,12 +20,14 @@ func NewInstallCmd(commandRunner runner.Runner, promptRunner prompt.Runner, conf
 	userFlag := custom_flags.NewEmptyStringFlag("user")
 	var allowFull bool
 	var dryRun bool
+	var verify bool
 	var presetFlags []string
 	var packageFlags []string
 
 	cmd := &cobra.Command{
 		Use:   "install [package] [packages...]",
 		Short: "Install Go binaries globally and save them to the global package list",
+		Long:  "Install Go binaries globally and save them to the global package list.\n\n" + modulePathHelp,
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validateInstallInputs(args)
 		},
@@ -35,19 +36,18 @@ func NewInstallCmd(commandRunner runner.Runner, promptRunner prompt.Runner, conf
 			if err != nil {
 				return err
 			}
-			promptPackages := []string(nil)
+			selection := packageSelection{}
 			if len(args) == 0 && len(packageFlags) == 0 && len(presetFlags) == 0 {
-				inputs, err := promptInstallPackages(cmd, promptRunner)
+				selection, err = promptInstallPackages(cmd, promptRunner, values)
 				if err != nil {
 					if errors.Is(err, huh.ErrUserAborted) {
 						return nil
 					}
-					return err
+					return cmdutil.MissingInput(err, "missing packages; pass them as arguments or use --package or --preset")
 				}
-				promptPackages = inputs
 			}
 
-			installPackages, err := resolveInstallPackages(values, packageFlags, presetFlags, promptPackages)
+			installPackages, err := resolveInstallPackages(values, packageFlags, append(presetFlags, selection.Presets...), selection.Packages)
 			if err != nil {
 				return err
 			}
@@ -87,12 +87,19 @@ func NewInstallCmd(commandRunner runner.Runner, promptRunner prompt.Runner, conf
 			if err != nil {
 				return err
 			}
+			if verify {
+				if err := verifyModulePaths(cmd, values, uniqueModules); err != nil {
+					return err
+				}
+			}
 
-			// Strip any existing version suffix and append @latest
-			installArgs := lo.Map(uniqueModules, func(mod string, _ int) string {
-				base := strings.Split(mod, "@")[0]
-				return base + "@latest"
+			globalPackages := lo.Map(uniqueModules, func(mod string, _ int) config.GlobalPackage {
+				return config.ParseGlobalPackage(mod)
 			})
+			installArgs, err := resolveGlobalInstallArgs(cmd, values, globalPackages)
+			if err != nil {
+				return err
+			}
 
 			if dryRun {
 				cmdutil.LogInfoIfProduction("install: dry run output")
@@ -109,14 +116,14 @@ func NewInstallCmd(commandRunner runner.Runner, promptRunner prompt.Runner, conf
 				}
 			}
 
-			// Save installed packages to the global packages list
-			basePaths := lo.Map(uniqueModules, func(mod string, _ int) string {
-				return strings.Split(mod, "@")[0]
-			})
-			values.GlobalPackages = lo.Uniq(append(values.GlobalPackages, basePaths...))
+			// Save installed packages, with any pinned version, to the global packages list
+			values.GlobalPackages = config.UpsertGlobalPackages(values.GlobalPackages, globalPackages...)
 			if err := config.Save(*configPath, values); err != nil {
 				return err
 			}
+			if err := recordGlobalLock(cmd, values, *configPath, installArgs, nil); err != nil {
+				return err
+			}
 
 			return cmdutil.WriteLine(cmd.OutOrStdout(), "installed and saved to global packages")
 		},
@@ -126,6 +133,7 @@ func NewInstallCmd(commandRunner runner.Runner, promptRunner prompt.Runner, conf
 	cmd.Flags().Var(&siteFlag, "site", "override the configured site")
 	cmd.Flags().BoolVar(&allowFull, "full", false, "allow a custom module site")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the go command without running it")
+	cmd.Flags().BoolVar(&verify, "verify", false, "check that each module exists on the module proxy first")
 	cmd.Flags().StringSliceVar(&packageFlags, "package", nil, "package preset entries or module paths to install")
 	cmd.Flags().StringSliceVar(&presetFlags, "preset", nil, "package preset names to install")
 	cmdutil.RegisterSiteCompletion(cmd, "site")
@@ -133,21 +141,8 @@ func NewInstallCmd(commandRunner runner.Runner, promptRunner prompt.Runner, conf
 	return cmd
 }
 
-func promptInstallPackages(cmd *cobra.Command, runner prompt.Runner) ([]string, error) {
-	packageInput, err := runner.Input(cmd, prompt.Input{
-		Title:       "Packages to install globally",
-		Description: "Use space-separated username/package or username/package/vN entries; presets can be used with --preset.",
-		Placeholder: "samber/lo onsi/ginkgo/v2",
-		Validate: func(value string) error {
-			_, err := validation.RequiredShortPackageList(value, "packages to install")
-			return err
-		},
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return validation.RequiredShortPackageList(packageInput, "packages to install")
+func promptInstallPackages(cmd *cobra.Command, runner prompt.Runner, values config.Values) (packageSelection, error) {
+	return promptPackageSelection(cmd, runner, values, "Packages to install globally", "packages to install")
 }
 
 func validateInstallInputs(inputs []string) error {
@@ -1,23 +1,39 @@
 package cmd
 
 import (
+	"bytes"
+	"fmt"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/louiss0/go-toolkit/custom_errors"
 	"github.com/louiss0/go-toolkit/internal/cmdutil"
+	"github.com/louiss0/go-toolkit/internal/globals"
 	"github.com/louiss0/go-toolkit/internal/modindex/config"
+	"github.com/louiss0/go-toolkit/internal/modproxy"
 	"github.com/louiss0/go-toolkit/internal/runner"
+	"github.com/louiss0/go-toolkit/internal/search"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
 func NewInstallGlobalsCmd(commandRunner runner.Runner, configPath *string) *cobra.Command {
 	var dryRun bool
+	var frozen bool
+	var jobs int
 
 	cmd := &cobra.Command{
 		Use:   "install-globals",
-		Short: "Install all saved global packages at their latest version",
+		Short: "Install all saved global packages at their recorded versions",
 		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if jobs < 1 {
+				return custom_errors.CreateInvalidInputErrorWithMessage("--jobs must be at least 1")
+			}
+
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			values, err := config.Load(*configPath)
 			if err != nil {
@@ -28,10 +44,20 @@ func NewInstallGlobalsCmd(commandRunner runner.Runner, configPath *string) *cobr
 				return custom_errors.CreateInvalidInputErrorWithMessage("no global packages saved; use install or config global-package add")
 			}
 
-			installArgs := lo.Map(values.GlobalPackages, func(mod string, _ int) string {
-				base := strings.Split(mod, "@")[0]
-				return base + "@latest"
-			})
+			lock, err := globals.LoadLock(globals.LockPath(*configPath))
+			if err != nil {
+				return err
+			}
+
+			var installArgs []string
+			if frozen {
+				installArgs, err = frozenInstallArgs(lock, values)
+			} else {
+				installArgs, err = resolveGlobalInstallArgs(cmd, values, values.GlobalPackages)
+			}
+			if err != nil {
+				return err
+			}
 
 			if dryRun {
 				cmdutil.LogInfoIfProduction("install-globals: dry run output")
@@ -41,11 +67,27 @@ func NewInstallGlobalsCmd(commandRunner runner.Runner, configPath *string) *cobr
 				return cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(lines, "\n"))
 			}
 
-			cmdutil.LogInfoIfProduction("install-globals: executing go install for %d packages", len(installArgs))
-			for _, arg := range installArgs {
-				if err := commandRunner.Run(cmd, "go", "install", arg); err != nil {
-					return err
+			cmdutil.LogInfoIfProduction("install-globals: executing go install for %d packages with %d jobs", len(installArgs), jobs)
+			installed, failures := installGlobalPackages(cmd, commandRunner, installArgs, jobs)
+
+			if frozen {
+				installedPaths := lo.Map(installed, func(arg string, _ int) string {
+					return strings.Split(arg, "@")[0]
+				})
+				if err := verifyFrozenBinaries(lock.Retain(installedPaths)); err != nil {
+					failures = append(failures, err.Error())
 				}
+			} else if err := recordGlobalLock(cmd, values, *configPath, installed, config.GlobalPackagePaths(values.GlobalPackages)); err != nil {
+				return err
+			}
+
+			if len(failures) > 0 {
+				return fmt.Errorf(
+					"failed to install %d of %d global packages:\n%s",
+					len(installArgs)-len(installed),
+					len(installArgs),
+					strings.Join(failures, "\n"),
+				)
 			}
 
 			return cmdutil.WriteLine(cmd.OutOrStdout(), "all global packages installed")
@@ -53,6 +95,107 @@ func NewInstallGlobalsCmd(commandRunner runner.Runner, configPath *string) *cobr
 	}
 
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the go commands without running them")
+	cmd.Flags().BoolVar(&frozen, "frozen", false, "install exactly the versions recorded in "+globals.LockFileName)
+	cmd.Flags().IntVar(&jobs, "jobs", runtime.NumCPU(), "number of packages to install at the same time")
 
 	return cmd
 }
+
+// installGlobalPackages runs go install for every argument on a pool of jobs
+// workers. It keeps going after failures, reports progress as each package
+// finishes, and returns the installed arguments in their original order along
+// with one message per failure.
+func installGlobalPackages(cmd *cobra.Command, commandRunner runner.Runner, installArgs []string, jobs int) ([]string, []string) {
+	results := make([]error, len(installArgs))
+	indexes := make(chan int)
+	var progress sync.Mutex
+	var workers sync.WaitGroup
+	finished := 0
+
+	for range min(jobs, len(installArgs)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for index := range indexes {
+				output, err := runGlobalInstall(cmd, commandRunner, installArgs[index])
+				results[index] = err
+
+				progress.Lock()
+				_, _ = output.WriteTo(cmd.OutOrStdout())
+				finished++
+				status := "installed " + installArgs[index]
+				if err != nil {
+					status = fmt.Sprintf("failed %s: %v", installArgs[index], err)
+				}
+				_ = cmdutil.WriteLine(cmd.OutOrStdout(), fmt.Sprintf("[%d/%d] %s", finished, len(installArgs), status))
+				progress.Unlock()
+			}
+		}()
+	}
+	for index := range installArgs {
+		indexes <- index
+	}
+	close(indexes)
+	workers.Wait()
+
+	installed := []string{}
+	failures := []string{}
+	for index, err := range results {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", installArgs[index], err))
+			continue
+		}
+		installed = append(installed, installArgs[index])
+	}
+
+	return installed, failures
+}
+
+// runGlobalInstall gives each go install its own output buffer so parallel
+// jobs never write to the command's streams at the same time.
+func runGlobalInstall(cmd *cobra.Command, commandRunner runner.Runner, installArg string) (*bytes.Buffer, error) {
+	output := new(bytes.Buffer)
+	jobCmd := &cobra.Command{}
+	jobCmd.SetContext(cmd.Context())
+	jobCmd.SetIn(bytes.NewReader(nil))
+	jobCmd.SetOut(output)
+	jobCmd.SetErr(output)
+
+	return output, commandRunner.Run(jobCmd, "go", "install", installArg)
+}
+
+// resolveGlobalInstallArgs turns saved packages into go install arguments.
+// Exact versions pass through, unpinned packages use @latest, and
+// constraints resolve to the newest matching version on the module proxy.
+func resolveGlobalInstallArgs(cmd *cobra.Command, values config.Values, packages []config.GlobalPackage) ([]string, error) {
+	var client *modproxy.Client
+	installArgs := make([]string, 0, len(packages))
+
+	for _, globalPackage := range packages {
+		switch {
+		case globalPackage.Version == "":
+			installArgs = append(installArgs, globalPackage.Path+"@latest")
+			continue
+		case globalPackage.IsExact():
+			installArgs = append(installArgs, globalPackage.String())
+			continue
+		}
+
+		if client == nil {
+			searchClient, err := newSearchClient(values, false)
+			if err != nil {
+				return nil, err
+			}
+			client = &searchClient
+		}
+
+		cmdutil.LogInfoIfProduction("install: resolving %s on %s", globalPackage, client)
+		version, err := search.ResolvePackageVersion(cmd.Context(), *client, globalPackage.Path, globalPackage.Version)
+		if err != nil {
+			return nil, err
+		}
+		installArgs = append(installArgs, globalPackage.Path+"@"+version)
+	}
+
+	return installArgs, nil
+}
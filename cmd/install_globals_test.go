@@ -1,12 +1,18 @@
 package cmd_test
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"github.com/louiss0/go-toolkit/cmd"
+	"github.com/louiss0/go-toolkit/internal/globals"
 	"github.com/louiss0/go-toolkit/internal/testhelpers"
 	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -38,6 +44,152 @@ var InstallGlobals = Describe("install-globals command", func() {
 		runner.AssertExpectations(GinkgoT())
 	})
 
+	It("installs pinned versions as recorded", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := os.WriteFile(configPath, []byte(`user = "lou"
+site = "github.com"
+
+[[global_packages]]
+path = "github.com/samber/lo"
+version = "v1.49.1"
+
+[[global_packages]]
+path = "github.com/stretchr/testify"
+`), 0o644)
+		assert.NoError(err)
+
+		runner.On("Run", mock.Anything, "go", []string{"install", "github.com/samber/lo@v1.49.1"}).Return(nil).Once()
+		runner.On("Run", mock.Anything, "go", []string{"install", "github.com/stretchr/testify@latest"}).Return(nil).Once()
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "install-globals")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+	})
+
+	It("resolves version constraints against the module proxy", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+		server := testhelpers.NewFakeProxy(map[string][]string{
+			"golang.org/x/tools": {"v0.20.0", "v0.21.0", "v0.30.0"},
+		})
+		DeferCleanup(server.Close)
+
+		err := os.WriteFile(configPath, []byte(`[search]
+proxy = "`+server.URL+`"
+
+[[global_packages]]
+path = "golang.org/x/tools/cmd/goimports"
+version = ">=0.20.0 <0.30.0"
+`), 0o644)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "install-globals", "--dry-run")
+
+		assert.NoError(err)
+		assert.Equal("go install golang.org/x/tools/cmd/goimports@v0.21.0\n", output)
+	})
+
+	It("keeps installing after a failure and reports every failure at the end", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := os.WriteFile(configPath, []byte("global_packages = [\"github.com/acme/broken\", \"github.com/samber/lo\", \"github.com/acme/gone\"]\n"), 0o644)
+		assert.NoError(err)
+
+		runner.On("Run", mock.Anything, "go", []string{"install", "github.com/acme/broken@latest"}).Return(errors.New("exit status 1")).Once()
+		runner.On("Run", mock.Anything, "go", []string{"install", "github.com/samber/lo@latest"}).Return(nil).Once()
+		runner.On("Run", mock.Anything, "go", []string{"install", "github.com/acme/gone@latest"}).Return(errors.New("exit status 2")).Once()
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output := new(bytes.Buffer)
+		rootCmd.SetOut(output)
+		rootCmd.SetErr(new(bytes.Buffer))
+		rootCmd.SetArgs([]string{"install-globals", "--jobs", "2"})
+
+		err = rootCmd.Execute()
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "failed to install 2 of 3 global packages")
+		assert.Contains(err.Error(), "github.com/acme/broken@latest: exit status 1")
+		assert.Contains(err.Error(), "github.com/acme/gone@latest: exit status 2")
+		assert.Contains(output.String(), "installed github.com/samber/lo@latest")
+		assert.Contains(output.String(), "[3/3]")
+		runner.AssertExpectations(GinkgoT())
+	})
+
+	It("keeps the output of each parallel install together", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := os.WriteFile(configPath, []byte("global_packages = [\"github.com/samber/lo\", \"github.com/samber/mo\", \"mvdan.cc/gofumpt\"]\n"), 0o644)
+		assert.NoError(err)
+
+		for _, arg := range []string{"github.com/samber/lo@latest", "github.com/samber/mo@latest", "mvdan.cc/gofumpt@latest"} {
+			runner.On("Run", mock.Anything, "go", []string{"install", arg}).Run(func(args mock.Arguments) {
+				jobCmd := args.Get(0).(*cobra.Command)
+				fmt.Fprintf(jobCmd.OutOrStdout(), "go: downloading %s\n", arg)
+				fmt.Fprintf(jobCmd.ErrOrStderr(), "go: built %s\n", arg)
+			}).Return(nil).Once()
+		}
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "install-globals", "--jobs", "3")
+
+		assert.NoError(err)
+		for _, arg := range []string{"github.com/samber/lo@latest", "github.com/samber/mo@latest", "mvdan.cc/gofumpt@latest"} {
+			assert.Regexp(regexp.MustCompile(regexp.QuoteMeta("go: downloading "+arg+"\ngo: built "+arg+"\n[")+`\d/3\] `+regexp.QuoteMeta("installed "+arg)), output)
+		}
+		runner.AssertExpectations(GinkgoT())
+	})
+
+	It("rejects fewer than one job", func() {
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := os.WriteFile(configPath, []byte("global_packages = [\"github.com/samber/lo\"]\n"), 0o644)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "install-globals", "--jobs", "0")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "--jobs must be at least 1")
+	})
+
 	It("prints dry run output for install-globals", func() {
 		runner := &testhelpers.RunnerMock{}
 		tempDir := GinkgoT().TempDir()
@@ -78,4 +230,222 @@ var InstallGlobals = Describe("install-globals command", func() {
 		assert.Error(err)
 		assert.Contains(err.Error(), "no global packages saved")
 	})
+
+	Describe("lock file", func() {
+		var binDir string
+
+		installBinary := func(name string) func(mock.Arguments) {
+			return func(mock.Arguments) {
+				executable, err := os.Executable()
+				assert.NoError(err)
+				content, err := os.ReadFile(executable)
+				assert.NoError(err)
+				assert.NoError(os.WriteFile(filepath.Join(binDir, name), content, 0o755))
+			}
+		}
+
+		BeforeEach(func() {
+			binDir = GinkgoT().TempDir()
+			GinkgoT().Setenv("GOBIN", binDir)
+		})
+
+		It("records installed binaries next to the config", func() {
+			runner := &testhelpers.RunnerMock{}
+			configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+			err := os.WriteFile(configPath, []byte("global_packages = [\"github.com/samber/lo@v1.49.1\"]\n"), 0o644)
+			assert.NoError(err)
+
+			runner.On("Run", mock.Anything, "go", []string{"install", "github.com/samber/lo@v1.49.1"}).Run(installBinary(globals.BinaryName("github.com/samber/lo"))).Return(nil).Once()
+
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			_, err = testhelpers.ExecuteCmd(rootCmd, "install-globals")
+			assert.NoError(err)
+
+			lock, err := globals.LoadLock(globals.LockPath(configPath))
+			assert.NoError(err)
+			entry, found := lock.Find("github.com/samber/lo")
+			assert.True(found)
+			assert.Equal("v1.49.1", entry.Version)
+			assert.Equal(globals.BinaryName("github.com/samber/lo"), entry.Binary)
+			assert.NotEmpty(entry.GoVersion)
+			assert.Contains(entry.Hash, "sha256:")
+		})
+
+		It("records the proxy's latest version when the binary reports no version", func() {
+			runner := &testhelpers.RunnerMock{}
+			configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+			server := testhelpers.NewFakeProxy(map[string][]string{
+				"github.com/samber/lo": {"v1.47.0", "v1.49.1"},
+			})
+			DeferCleanup(server.Close)
+
+			err := os.WriteFile(configPath, []byte(`global_packages = ["github.com/samber/lo"]
+
+[search]
+proxy = "`+server.URL+`"
+`), 0o644)
+			assert.NoError(err)
+
+			runner.On("Run", mock.Anything, "go", []string{"install", "github.com/samber/lo@latest"}).Run(installBinary(globals.BinaryName("github.com/samber/lo"))).Return(nil).Once()
+
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			_, err = testhelpers.ExecuteCmd(rootCmd, "install-globals")
+			assert.NoError(err)
+
+			lock, err := globals.LoadLock(globals.LockPath(configPath))
+			assert.NoError(err)
+			entry, found := lock.Find("github.com/samber/lo")
+			assert.True(found)
+			assert.Equal("v1.49.1", entry.Version)
+		})
+
+		It("leaves a binary out of the lock when its version cannot be resolved", func() {
+			runner := &testhelpers.RunnerMock{}
+			configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+			server := testhelpers.NewFakeProxy(map[string][]string{})
+			DeferCleanup(server.Close)
+
+			err := os.WriteFile(configPath, []byte(`global_packages = ["github.com/samber/lo"]
+
+[search]
+proxy = "`+server.URL+`"
+`), 0o644)
+			assert.NoError(err)
+
+			runner.On("Run", mock.Anything, "go", []string{"install", "github.com/samber/lo@latest"}).Run(installBinary(globals.BinaryName("github.com/samber/lo"))).Return(nil).Once()
+
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			_, err = testhelpers.ExecuteCmd(rootCmd, "install-globals")
+			assert.NoError(err)
+
+			lock, err := globals.LoadLock(globals.LockPath(configPath))
+			assert.NoError(err)
+			_, found := lock.Find("github.com/samber/lo")
+			assert.False(found)
+		})
+
+		buildBinaryAt := func(version string) func(mock.Arguments) {
+			return func(mock.Arguments) {
+				binaryPath := filepath.Join(binDir, globals.BinaryName("github.com/samber/lo"))
+				assert.NoError(testhelpers.BuildGoBinaryAt(binaryPath, "github.com/samber/lo", version))
+			}
+		}
+
+		saveFrozenLock := func(configPath string) {
+			buildBinaryAt("v1.47.0")(nil)
+			binary, err := globals.InspectBinary(filepath.Join(binDir, globals.BinaryName("github.com/samber/lo")))
+			assert.NoError(err)
+			assert.NoError(globals.SaveLock(globals.LockPath(configPath), globals.Lock{Packages: []globals.Entry{
+				{
+					Path:      "github.com/samber/lo",
+					Module:    "github.com/samber/lo",
+					Version:   "v1.47.0",
+					GoVersion: binary.GoVersion,
+					Binary:    globals.BinaryName("github.com/samber/lo"),
+					Hash:      "sha256:built-on-another-platform",
+				},
+			}}))
+		}
+
+		It("installs strictly from the lock when frozen without comparing hashes", func() {
+			runner := &testhelpers.RunnerMock{}
+			configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+			err := os.WriteFile(configPath, []byte("global_packages = [\"github.com/samber/lo\"]\n"), 0o644)
+			assert.NoError(err)
+			saveFrozenLock(configPath)
+
+			runner.On("Run", mock.Anything, "go", []string{"install", "github.com/samber/lo@v1.47.0"}).Run(buildBinaryAt("v1.47.0")).Return(nil).Once()
+
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			output, err := testhelpers.ExecuteCmd(rootCmd, "install-globals", "--frozen")
+
+			assert.NoError(err)
+			assert.Contains(output, "all global packages installed")
+			runner.AssertExpectations(GinkgoT())
+		})
+
+		It("rejects frozen installs when the binary reports a different version", func() {
+			runner := &testhelpers.RunnerMock{}
+			configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+			err := os.WriteFile(configPath, []byte("global_packages = [\"github.com/samber/lo\"]\n"), 0o644)
+			assert.NoError(err)
+			saveFrozenLock(configPath)
+
+			runner.On("Run", mock.Anything, "go", []string{"install", "github.com/samber/lo@v1.47.0"}).Run(buildBinaryAt("v1.48.0")).Return(nil).Once()
+
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			_, err = testhelpers.ExecuteCmd(rootCmd, "install-globals", "--frozen")
+
+			assert.Error(err)
+			assert.Contains(err.Error(), "is at v1.48.0, locked at v1.47.0")
+		})
+
+		It("rejects frozen installs when the config has unlocked packages", func() {
+			configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+			err := os.WriteFile(configPath, []byte("global_packages = [\"github.com/samber/lo\", \"mvdan.cc/gofumpt\"]\n"), 0o644)
+			assert.NoError(err)
+			err = globals.SaveLock(globals.LockPath(configPath), globals.Lock{Packages: []globals.Entry{
+				{Path: "github.com/samber/lo", Version: "v1.47.0", Binary: "lo", Hash: "sha256:a"},
+			}})
+			assert.NoError(err)
+
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       &testhelpers.RunnerMock{},
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			_, err = testhelpers.ExecuteCmd(rootCmd, "install-globals", "--frozen")
+
+			assert.Error(err)
+			assert.Contains(err.Error(), "mvdan.cc/gofumpt is not locked")
+		})
+
+		It("rejects frozen installs without a lock", func() {
+			configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+			err := os.WriteFile(configPath, []byte("global_packages = [\"github.com/samber/lo\"]\n"), 0o644)
+			assert.NoError(err)
+
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       &testhelpers.RunnerMock{},
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			_, err = testhelpers.ExecuteCmd(rootCmd, "install-globals", "--frozen")
+
+			assert.Error(err)
+			assert.Contains(err.Error(), "run install-globals without --frozen first")
+		})
+	})
 })
@@ -39,7 +39,7 @@ var Install = Describe("install command", func() {
 
 		values, err := config.Load(configPath)
 		assert.NoError(err)
-		assert.Contains(values.GlobalPackages, "github.com/onsi/ginkgo/v2")
+		assert.Contains(config.GlobalPackagePaths(values.GlobalPackages), "github.com/onsi/ginkgo/v2")
 	})
 
 	It("installs multiple packages globally", func() {
@@ -66,8 +66,8 @@ var Install = Describe("install command", func() {
 
 		values, err := config.Load(configPath)
 		assert.NoError(err)
-		assert.Contains(values.GlobalPackages, "github.com/onsi/ginkgo/v2")
-		assert.Contains(values.GlobalPackages, "github.com/carapace-sh/carapace")
+		assert.Contains(config.GlobalPackagePaths(values.GlobalPackages), "github.com/onsi/ginkgo/v2")
+		assert.Contains(config.GlobalPackagePaths(values.GlobalPackages), "github.com/carapace-sh/carapace")
 	})
 
 	It("installs a short package path with a major version suffix globally", func() {
@@ -93,7 +93,33 @@ var Install = Describe("install command", func() {
 
 		values, err := config.Load(configPath)
 		assert.NoError(err)
-		assert.Contains(values.GlobalPackages, "github.com/onsi/ginkgo/v2")
+		assert.Contains(config.GlobalPackagePaths(values.GlobalPackages), "github.com/onsi/ginkgo/v2")
+	})
+
+	It("installs and records a pinned version", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\nglobal_packages = [\"github.com/onsi/ginkgo/v2\"]\n"), 0o644)
+		assert.NoError(err)
+
+		runner.On("Run", mock.Anything, "go", []string{"install", "github.com/onsi/ginkgo/v2@v2.27.3"}).Return(nil).Once()
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "install", "onsi/ginkgo/v2@v2.27.3")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Equal([]config.GlobalPackage{{Path: "github.com/onsi/ginkgo/v2", Version: "v2.27.3"}}, values.GlobalPackages)
 	})
 
 	It("prints the install command on dry run", func() {
@@ -117,6 +143,25 @@ var Install = Describe("install command", func() {
 		assert.Contains(output, "go install github.com/onsi/ginkgo/v2@latest")
 	})
 
+	It("installs two element paths on a dotted host as full module paths", func() {
+		runner := &testhelpers.RunnerMock{}
+		configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+		err := writeDefaultConfig(configPath)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "install", "mvdan.cc/gofumpt", "--dry-run")
+
+		assert.NoError(err)
+		assert.Equal("go install mvdan.cc/gofumpt@latest\n", output)
+	})
+
 	It("does not duplicate global packages on repeated install", func() {
 		runner := &testhelpers.RunnerMock{}
 		tempDir := GinkgoT().TempDir()
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/louiss0/go-toolkit/custom_errors"
+	"github.com/louiss0/go-toolkit/internal/cmdutil"
+	"github.com/louiss0/go-toolkit/internal/globals"
+	"github.com/louiss0/go-toolkit/internal/modindex/config"
+	"github.com/louiss0/go-toolkit/internal/modproxy"
+	"github.com/louiss0/go-toolkit/internal/runner"
+	"github.com/louiss0/go-toolkit/internal/search"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outdatedStatusCurrent  = "current"
+	outdatedStatusOutdated = "outdated"
+	outdatedStatusPinned   = "pinned"
+	outdatedStatusMissing  = "missing"
+	outdatedStatusError    = "error"
+)
+
+type outdatedPackage struct {
+	Path    string `json:"path"`
+	Binary  string `json:"binary"`
+	Current string `json:"current"`
+	Wanted  string `json:"wanted"`
+	Latest  string `json:"latest"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (p outdatedPackage) Stale() bool {
+	return p.Status == outdatedStatusOutdated || p.Status == outdatedStatusMissing
+}
+
+func NewOutdatedCmd(commandRunner runner.Runner, configPath *string) *cobra.Command {
+	var jsonOutput bool
+	var upgrade bool
+	var jobs int
+
+	cmd := &cobra.Command{
+		Use:   "outdated",
+		Short: "Compare installed global binaries with the versions on the module proxy",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if jobs < 1 {
+				return custom_errors.CreateInvalidInputErrorWithMessage("--jobs must be at least 1")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			values, err := config.Load(*configPath)
+			if err != nil {
+				return err
+			}
+
+			if len(values.GlobalPackages) == 0 {
+				return custom_errors.CreateInvalidInputErrorWithMessage("no global packages saved; use install or config global-package add")
+			}
+
+			report, err := buildOutdatedReport(cmd, values)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				if err := cmdutil.WritePrettyJSON(cmd.OutOrStdout(), report); err != nil {
+					return err
+				}
+			} else if err := writeOutdatedTable(cmd, report); err != nil {
+				return err
+			}
+
+			if !upgrade {
+				return nil
+			}
+
+			installArgs := lo.FilterMap(report, func(pkg outdatedPackage, _ int) (string, bool) {
+				return pkg.Path + "@" + pkg.Wanted, pkg.Stale()
+			})
+			cmdutil.LogInfoIfProduction("outdated: upgrading %d packages with %d jobs", len(installArgs), jobs)
+			progressCmd := cmd
+			if jsonOutput {
+				// Keep install progress out of the JSON report on stdout.
+				progressCmd = &cobra.Command{}
+				progressCmd.SetContext(cmd.Context())
+				progressCmd.SetOut(cmd.ErrOrStderr())
+				progressCmd.SetErr(cmd.ErrOrStderr())
+			}
+			installed, failures := installGlobalPackages(progressCmd, commandRunner, installArgs, jobs)
+			if err := recordGlobalLock(cmd, values, *configPath, installed, nil); err != nil {
+				return err
+			}
+
+			if len(failures) > 0 {
+				return fmt.Errorf(
+					"failed to upgrade %d of %d global packages:\n%s",
+					len(installArgs)-len(installed),
+					len(installArgs),
+					strings.Join(failures, "\n"),
+				)
+			}
+
+			if jsonOutput {
+				return nil
+			}
+
+			return cmdutil.WriteLine(cmd.OutOrStdout(), fmt.Sprintf("upgraded %d global packages", len(installed)))
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the report as JSON")
+	cmd.Flags().BoolVar(&upgrade, "upgrade", false, "reinstall packages that are missing or behind their wanted version")
+	cmd.Flags().IntVar(&jobs, "jobs", runtime.NumCPU(), "number of packages to upgrade at the same time")
+
+	return cmd
+}
+
+// buildOutdatedReport reads the version embedded in each installed binary and
+// compares it with the version the config asks for and the proxy's latest.
+// A package whose versions cannot be resolved keeps its row with the error.
+func buildOutdatedReport(cmd *cobra.Command, values config.Values) ([]outdatedPackage, error) {
+	client, err := newSearchClient(values, false)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]outdatedPackage, 0, len(values.GlobalPackages))
+	for _, globalPackage := range values.GlobalPackages {
+		cmdutil.LogInfoIfProduction("outdated: checking %s on %s", globalPackage.Path, client)
+		pkg := outdatedPackage{
+			Path:   globalPackage.Path,
+			Binary: globals.BinaryName(globalPackage.Path),
+		}
+		binary, inspectErr := globals.InspectBinary(filepath.Join(globals.BinDir(), pkg.Binary))
+		pkg.Current = binary.Version
+
+		pkg.Latest, pkg.Wanted, err = resolveOutdatedVersions(cmd, client, globalPackage)
+		switch {
+		case err != nil:
+			pkg.Status = outdatedStatusError
+			pkg.Error = err.Error()
+		case inspectErr != nil:
+			pkg.Status = outdatedStatusMissing
+		case pkg.Current != pkg.Wanted:
+			pkg.Status = outdatedStatusOutdated
+		case pkg.Current != pkg.Latest:
+			pkg.Status = outdatedStatusPinned
+		default:
+			pkg.Status = outdatedStatusCurrent
+		}
+		report = append(report, pkg)
+	}
+
+	return report, nil
+}
+
+func resolveOutdatedVersions(cmd *cobra.Command, client modproxy.Client, globalPackage config.GlobalPackage) (string, string, error) {
+	latest, err := search.ResolvePackageVersion(cmd.Context(), client, globalPackage.Path, "latest")
+	if err != nil {
+		return "", "", err
+	}
+
+	switch {
+	case globalPackage.IsExact():
+		return latest, globalPackage.Version, nil
+	case globalPackage.Version != "":
+		wanted, err := search.ResolvePackageVersion(cmd.Context(), client, globalPackage.Path, globalPackage.Version)
+		return latest, wanted, err
+	default:
+		return latest, latest, nil
+	}
+}
+
+func writeOutdatedTable(cmd *cobra.Command, report []outdatedPackage) error {
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "PACKAGE\tCURRENT\tWANTED\tLATEST\tSTATUS")
+	for _, pkg := range report {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", pkg.Path, lo.CoalesceOrEmpty(pkg.Current, "-"), lo.CoalesceOrEmpty(pkg.Wanted, "-"), lo.CoalesceOrEmpty(pkg.Latest, "-"), pkg.Status)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	failed := lo.Filter(report, func(pkg outdatedPackage, _ int) bool {
+		return pkg.Error != ""
+	})
+	if len(failed) == 0 {
+		return nil
+	}
+
+	lines := lo.Map(failed, func(pkg outdatedPackage, _ int) string {
+		return fmt.Sprintf("%s: %s", pkg.Path, pkg.Error)
+	})
+	return cmdutil.WriteLine(cmd.OutOrStdout(), "\nerrors:\n"+strings.Join(lines, "\n"))
+}
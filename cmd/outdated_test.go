@@ -0,0 +1,168 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/louiss0/go-toolkit/cmd"
+	"github.com/louiss0/go-toolkit/internal/testhelpers"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var Outdated = Describe("outdated command", func() {
+	assert := assert.New(GinkgoT())
+
+	var configPath string
+	var proxyURL string
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("GOBIN", GinkgoT().TempDir())
+		server := testhelpers.NewFakeProxy(map[string][]string{
+			"github.com/samber/lo": {"v1.47.0", "v1.49.1"},
+			"golang.org/x/tools":   {"v0.20.0", "v0.21.0"},
+		})
+		DeferCleanup(server.Close)
+		proxyURL = server.URL
+
+		configPath = filepath.Join(GinkgoT().TempDir(), "config.toml")
+		err := os.WriteFile(configPath, []byte(`global_packages = ["github.com/samber/lo", "golang.org/x/tools/cmd/goimports@v0.20.0"]
+
+[search]
+proxy = "`+server.URL+`"
+`), 0o644)
+		assert.NoError(err)
+	})
+
+	It("reports wanted and latest versions as JSON", func() {
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "outdated", "--json")
+		assert.NoError(err)
+
+		var report []map[string]string
+		assert.NoError(json.Unmarshal([]byte(output), &report))
+		assert.Len(report, 2)
+		assert.Equal("github.com/samber/lo", report[0]["path"])
+		assert.Equal("v1.49.1", report[0]["wanted"])
+		assert.Equal("missing", report[0]["status"])
+		assert.Equal("v0.20.0", report[1]["wanted"])
+		assert.Equal("v0.21.0", report[1]["latest"])
+	})
+
+	It("prints a table", func() {
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "outdated")
+
+		assert.NoError(err)
+		assert.Contains(output, "PACKAGE")
+		assert.Regexp(`github.com/samber/lo\s+-\s+v1.49.1\s+v1.49.1\s+missing`, output)
+	})
+
+	It("reinstalls stale packages at their wanted version", func() {
+		runner := &testhelpers.RunnerMock{}
+		runner.On("Run", mock.Anything, "go", []string{"install", "github.com/samber/lo@v1.49.1"}).Return(nil).Once()
+		runner.On("Run", mock.Anything, "go", []string{"install", "golang.org/x/tools/cmd/goimports@v0.20.0"}).Return(nil).Once()
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "outdated", "--upgrade")
+
+		assert.NoError(err)
+		assert.Contains(output, "upgraded 2 global packages")
+		runner.AssertExpectations(GinkgoT())
+	})
+
+	Context("when a package is not on the proxy", func() {
+		BeforeEach(func() {
+			err := os.WriteFile(configPath, []byte(`global_packages = ["example.com/gone/tool", "github.com/samber/lo"]
+
+[search]
+proxy = "`+proxyURL+`"
+`), 0o644)
+			assert.NoError(err)
+		})
+
+		It("records the error on its row and reports the rest", func() {
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       &testhelpers.RunnerMock{},
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			output, err := testhelpers.ExecuteCmd(rootCmd, "outdated", "--json")
+			assert.NoError(err)
+
+			var report []map[string]string
+			assert.NoError(json.Unmarshal([]byte(output), &report))
+			assert.Len(report, 2)
+			assert.Equal("error", report[0]["status"])
+			assert.NotEmpty(report[0]["error"])
+			assert.Equal("v1.49.1", report[1]["wanted"])
+			assert.Empty(report[1]["error"])
+		})
+
+		It("marks the row in the table", func() {
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       &testhelpers.RunnerMock{},
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			output, err := testhelpers.ExecuteCmd(rootCmd, "outdated")
+
+			assert.NoError(err)
+			assert.Regexp(`example.com/gone/tool\s+-\s+-\s+-\s+error`, output)
+			assert.Contains(output, "errors:\nexample.com/gone/tool: ")
+			assert.Regexp(`github.com/samber/lo\s+-\s+v1.49.1\s+v1.49.1\s+missing`, output)
+		})
+	})
+
+	It("upgrades the remaining packages when one install fails", func() {
+		runner := &testhelpers.RunnerMock{}
+		runner.On("Run", mock.Anything, "go", []string{"install", "github.com/samber/lo@v1.49.1"}).Return(errors.New("exit status 1")).Once()
+		runner.On("Run", mock.Anything, "go", []string{"install", "golang.org/x/tools/cmd/goimports@v0.20.0"}).Return(nil).Once()
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err := testhelpers.ExecuteCmd(rootCmd, "outdated", "--upgrade", "--jobs", "1")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "failed to upgrade 1 of 2 global packages")
+		assert.Contains(err.Error(), "github.com/samber/lo@v1.49.1: exit status 1")
+		runner.AssertExpectations(GinkgoT())
+	})
+
+	It("rejects a job count below one", func() {
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err := testhelpers.ExecuteCmd(rootCmd, "outdated", "--upgrade", "--jobs", "0")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "--jobs must be at least 1")
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/huh"
+	"github.com/louiss0/go-toolkit/custom_errors"
 	"github.com/louiss0/go-toolkit/internal/cmdutil"
 	"github.com/louiss0/go-toolkit/internal/modindex/config"
 	"github.com/louiss0/go-toolkit/internal/packagepath"
@@ -29,6 +30,62 @@ func resolveInstallPackages(values config.Values, packageFlags []string, presetF
 	return lo.Uniq(packages), nil
 }
 
+type packageSelection struct {
+	Presets  []string
+	Packages []string
+}
+
+func promptPackageSelection(cmd *cobra.Command, runner prompt.Runner, values config.Values, title string, field string) (packageSelection, error) {
+	selection := packageSelection{}
+
+	if presetNames := config.KnownPackagePresetNames(values); len(presetNames) > 0 {
+		presets, err := runner.MultiSelect(cmd, prompt.MultiSelect{
+			Title: "Package presets",
+			Options: lo.Map(presetNames, func(name string, _ int) prompt.Option {
+				return prompt.Option{Label: name, Value: name}
+			}),
+		})
+		if err != nil {
+			return packageSelection{}, err
+		}
+		selection.Presets = presets
+	}
+
+	parsePackages := validation.RequiredShortPackageList
+	description := "One username/package or username/package/vN entry per line."
+	if len(selection.Presets) > 0 {
+		parsePackages = validation.ParseShortPackageList
+		description = "Optional; one username/package or username/package/vN entry per line, or leave blank to use the selected presets."
+	}
+
+	packageInput, err := runner.Text(cmd, prompt.Text{
+		Title:       title,
+		Description: description,
+		Placeholder: "samber/lo\nonsi/ginkgo/v2",
+		Validate: func(value string) error {
+			_, err := parsePackages(value, field)
+			return err
+		},
+	})
+	if err != nil {
+		return packageSelection{}, err
+	}
+
+	selection.Packages, err = parsePackages(packageInput, field)
+	if err != nil {
+		return packageSelection{}, err
+	}
+
+	return selection, nil
+}
+
+// modulePathHelp describes how package arguments expand to module paths. It
+// is shared by the commands that call resolveModulePaths.
+const modulePathHelp = `Package arguments expand to module paths on the configured or --site site:
+  name           site/user/name, using the configured or --user user
+  scope/name     site/scope/name; scope/name/vN keeps the major version
+  host.tld/path  a path whose first element contains a dot is used as is`
+
 func resolveModulePaths(packages []string, site string, user string) ([]string, error) {
 	modulePaths := make([]string, 0, len(packages))
 
@@ -44,6 +101,37 @@ func resolveModulePaths(packages []string, site string, user string) ([]string,
 	return lo.Uniq(modulePaths), nil
 }
 
+func verifyModulePaths(cmd *cobra.Command, values config.Values, modulePaths []string) error {
+	client, err := newSearchClient(values, false)
+	if err != nil {
+		return err
+	}
+	problems := []string{}
+
+	for _, modulePath := range modulePaths {
+		cmdutil.LogInfoIfProduction("verify: checking %s on %s", modulePath, client)
+		verification, err := client.Verify(cmd.Context(), modulePath)
+		if err != nil {
+			return err
+		}
+		if verification.Found {
+			continue
+		}
+
+		problem := fmt.Sprintf("module %s was not found on %s", modulePath, client)
+		if len(verification.Suggestions) > 0 {
+			problem += "; did you mean " + strings.Join(verification.Suggestions, " or ") + "?"
+		}
+		problems = append(problems, problem)
+	}
+
+	if len(problems) > 0 {
+		return custom_errors.CreateInvalidInputErrorWithMessage(strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
 const (
 	packageProviderUseDefault = "use-default"
 	packageProviderEdit       = "edit"
@@ -68,6 +156,9 @@ func assurePackageProviders(cmd *cobra.Command, runner prompt.Runner, values con
 			{Label: "Edit providers", Value: packageProviderEdit},
 		},
 	})
+	if errors.Is(err, prompt.ErrNoInput) {
+		return packages, nil
+	}
 	if err != nil {
 		if errors.Is(err, huh.ErrUserAborted) {
 			return nil, err
@@ -12,14 +12,21 @@ import (
 type promptStepKind string
 
 const (
-	promptStepInput  promptStepKind = "input"
-	promptStepSelect promptStepKind = "select"
+	promptStepInput       promptStepKind = "input"
+	promptStepSelect      promptStepKind = "select"
+	promptStepMultiSelect promptStepKind = "multi-select"
+	promptStepConfirm     promptStepKind = "confirm"
+	promptStepText        promptStepKind = "text"
+	promptStepForm        promptStepKind = "form"
 )
 
 type promptStep struct {
-	kind  promptStepKind
-	value string
-	err   error
+	kind      promptStepKind
+	value     string
+	values    []string
+	confirmed bool
+	answers   prompt.Answers
+	err       error
 }
 
 type promptMock struct {
@@ -64,6 +71,63 @@ func (m *promptMock) Select(_ *cobra.Command, selectInput prompt.Select) (string
 	return step.value, nil
 }
 
+func (m *promptMock) MultiSelect(_ *cobra.Command, multiSelect prompt.MultiSelect) ([]string, error) {
+	step, err := m.next(promptStepMultiSelect)
+	if err != nil {
+		return nil, err
+	}
+	if step.err != nil {
+		return nil, step.err
+	}
+	for _, value := range step.values {
+		optionExists := lo.ContainsBy(multiSelect.Options, func(option prompt.Option) bool {
+			return option.Value == value
+		})
+		if !optionExists {
+			return nil, fmt.Errorf("unexpected selection: %s", value)
+		}
+	}
+	return step.values, nil
+}
+
+func (m *promptMock) Confirm(_ *cobra.Command, _ prompt.Confirm) (bool, error) {
+	step, err := m.next(promptStepConfirm)
+	if err != nil {
+		return false, err
+	}
+	if step.err != nil {
+		return false, step.err
+	}
+	return step.confirmed, nil
+}
+
+func (m *promptMock) Text(_ *cobra.Command, text prompt.Text) (string, error) {
+	step, err := m.next(promptStepText)
+	if err != nil {
+		return "", err
+	}
+	if step.err != nil {
+		return "", step.err
+	}
+	if text.Validate != nil {
+		if err := text.Validate(step.value); err != nil {
+			return "", err
+		}
+	}
+	return step.value, nil
+}
+
+func (m *promptMock) Form(_ *cobra.Command, form prompt.Form) (prompt.Answers, error) {
+	step, err := m.next(promptStepForm)
+	if err != nil {
+		return nil, err
+	}
+	if step.err != nil {
+		return nil, step.err
+	}
+	return form.Merge(step.answers)
+}
+
 func (m *promptMock) next(expected promptStepKind) (promptStep, error) {
 	if m.index >= len(m.steps) {
 		return promptStep{}, errors.New("prompt mock: no steps remaining")
@@ -19,6 +19,7 @@ func NewRemoveCmd(commandRunner runner.Runner, configPath *string) *cobra.Comman
 	userFlag := custom_flags.NewEmptyStringFlag("user")
 	var allowFull bool
 	var dryRun bool
+	var jsonOutput bool
 
 	cmd := &cobra.Command{
 		Use:   "remove <package> [packages...]",
@@ -80,12 +81,7 @@ func NewRemoveCmd(commandRunner runner.Runner, configPath *string) *cobra.Comman
 				)
 			}
 
-			cmdutil.LogInfoIfProduction("remove: executing go get")
-			if err := commandRunner.Run(cmd, "go", append([]string{"get"}, uniqueModules...)...); err != nil {
-				return err
-			}
-
-			return nil
+			return runGoGetWithDiff(cmd, commandRunner, "remove", uniqueModules, jsonOutput)
 		},
 	}
 
@@ -93,6 +89,7 @@ func NewRemoveCmd(commandRunner runner.Runner, configPath *string) *cobra.Comman
 	cmd.Flags().Var(&siteFlag, "site", "override the configured site")
 	cmd.Flags().BoolVar(&allowFull, "full", false, "allow a custom module site")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the go command without running it")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print requirement changes as JSON")
 	cmdutil.RegisterSiteCompletion(cmd, "site")
 
 	return cmd
@@ -1,6 +1,7 @@
 package cmd_test
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 
@@ -116,4 +117,46 @@ var Remove = Describe("remove command", func() {
 		runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 		assert.Contains(output, "go get github.com/onsi/ginkgo/v2@none")
 	})
+
+	It("prints removed requirements as JSON", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+		modFilePath := filepath.Join(tempDir, "go.mod")
+		workingDir, err := os.Getwd()
+		assert.NoError(err)
+
+		err = writeDefaultConfig(configPath)
+		assert.NoError(err)
+		err = os.WriteFile(modFilePath, []byte("module github.com/lou/toolkit\n\ngo 1.24\n\nrequire github.com/acme/tool v1.2.0\n"), 0o644)
+		assert.NoError(err)
+
+		err = os.Chdir(tempDir)
+		assert.NoError(err)
+		DeferCleanup(func() {
+			_ = os.Chdir(workingDir)
+		})
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		runner.On("Run", mock.Anything, "go", []string{"get", "github.com/acme/tool@none"}).
+			Run(func(mock.Arguments) {
+				_ = os.WriteFile(modFilePath, []byte("module github.com/lou/toolkit\n\ngo 1.24\n"), 0o644)
+			}).
+			Return(nil).Once()
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "remove", "github.com/acme/tool", "--json")
+
+		assert.NoError(err)
+
+		var changes map[string][]map[string]any
+		err = json.Unmarshal([]byte(output), &changes)
+		assert.NoError(err)
+		assert.Empty(changes["added"])
+		assert.Equal([]map[string]any{{"path": "github.com/acme/tool", "from": "v1.2.0", "indirect": false}}, changes["removed"])
+	})
 })
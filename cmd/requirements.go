@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/louiss0/go-toolkit/internal/cmdutil"
+	"github.com/louiss0/go-toolkit/internal/gomod"
+	"github.com/louiss0/go-toolkit/internal/runner"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+type requirementChange struct {
+	Path     string `json:"path"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+	Indirect bool   `json:"indirect"`
+}
+
+type requirementChanges struct {
+	Added      []requirementChange `json:"added"`
+	Upgraded   []requirementChange `json:"upgraded"`
+	Downgraded []requirementChange `json:"downgraded"`
+	Promoted   []requirementChange `json:"promoted"`
+	Removed    []requirementChange `json:"removed"`
+}
+
+func runGoGetWithDiff(cmd *cobra.Command, commandRunner runner.Runner, logPrefix string, modules []string, jsonOutput bool) error {
+	modFilePath, err := gomod.FindModFile(".")
+	if err != nil && !errors.Is(err, gomod.ErrModFileNotFound) {
+		return err
+	}
+
+	var before map[string]gomod.Requirement
+	if modFilePath != "" {
+		before, err = gomod.ReadRequirements(modFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmdutil.LogInfoIfProduction("%s: executing go get", logPrefix)
+	if err := commandRunner.Run(cmd, "go", append([]string{"get"}, modules...)...); err != nil {
+		return err
+	}
+
+	if modFilePath == "" {
+		cmdutil.LogInfoIfProduction("%s: no go.mod found; skipping requirement diff", logPrefix)
+		if jsonOutput {
+			return writeRequirementChanges(cmd, gomod.Diff(nil, nil), jsonOutput)
+		}
+		return nil
+	}
+
+	after, err := gomod.ReadRequirements(modFilePath)
+	if err != nil {
+		return err
+	}
+
+	return writeRequirementChanges(cmd, gomod.Diff(before, after), jsonOutput)
+}
+
+func writeRequirementChanges(cmd *cobra.Command, changes gomod.Changes, jsonOutput bool) error {
+	if jsonOutput {
+		toJSON := func(changes []gomod.Change) []requirementChange {
+			return lo.Map(changes, func(change gomod.Change, _ int) requirementChange {
+				return requirementChange{Path: change.Path, From: change.From, To: change.To, Indirect: change.Indirect}
+			})
+		}
+
+		return cmdutil.WritePrettyJSON(cmd.OutOrStdout(), requirementChanges{
+			Added:      toJSON(changes.Added),
+			Upgraded:   toJSON(changes.Upgraded),
+			Downgraded: toJSON(changes.Downgraded),
+			Promoted:   toJSON(changes.Promoted),
+			Removed:    toJSON(changes.Removed),
+		})
+	}
+
+	if changes.Empty() {
+		return cmdutil.WriteLine(cmd.OutOrStdout(), "no requirement changes")
+	}
+
+	return cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(changes.Lines(), "\n"))
+}
@@ -23,6 +23,7 @@ package cmd
 
 import (
 	"context"
+	"os"
 
 	"github.com/carapace-sh/carapace"
 	"github.com/charmbracelet/fang"
@@ -33,14 +34,16 @@ import (
 	"github.com/louiss0/go-toolkit/internal/modindex/config"
 	"github.com/louiss0/go-toolkit/internal/prompt"
 	"github.com/louiss0/go-toolkit/internal/runner"
+	"github.com/louiss0/go-toolkit/internal/search"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
 type RootOptions struct {
-	Runner       runner.Runner
-	PromptRunner prompt.Runner
-	ConfigPath   string `gozod:"regex=^$|^\\S+$"`
+	Runner        runner.Runner
+	PromptRunner  prompt.Runner
+	SearchBackend search.Backend
+	ConfigPath    string `gozod:"regex=^$|^\\S+$"`
 }
 
 var rootOptionsSchema = gozod.FromStruct[RootOptions]().
@@ -56,8 +59,9 @@ var rootOptionsSchema = gozod.FromStruct[RootOptions]().
 
 func NewRootCmd() *cobra.Command {
 	return NewRootCmdWithOptions(RootOptions{
-		Runner:       runner.ExecRunner{},
-		PromptRunner: prompt.NewRunner(mode.NewModeOperator()),
+		Runner:        runner.ExecRunner{},
+		PromptRunner:  prompt.NewRunner(mode.NewModeOperator()),
+		SearchBackend: search.NewIndexBackend(),
 	})
 }
 
@@ -73,9 +77,14 @@ func NewRootCmdWithOptions(options RootOptions) *cobra.Command {
 	}
 
 	commandRunner := options.Runner
-	promptRunner := options.PromptRunner
+	promptRunner := prompt.NewAnswerRunner(options.PromptRunner)
+	searchBackend := options.SearchBackend
+	if searchBackend == nil {
+		searchBackend = search.NewIndexBackend()
+	}
 
 	configPath := config.ResolveConfigPath(options.ConfigPath)
+	answersPath := ""
 
 	cmd := &cobra.Command{
 		Use:   "go-toolkit",
@@ -85,6 +94,9 @@ It shortens common tasks like init, remove, and scaffold.`,
 		CompletionOptions: cobra.CompletionOptions{
 			DisableDefaultCmd: true,
 		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return promptRunner.Load(answersPath, os.Environ())
+		},
 	}
 	cmd.AddGroup(
 		&cobra.Group{ID: "setup", Title: "Setup Commands"},
@@ -95,6 +107,9 @@ It shortens common tasks like init, remove, and scaffold.`,
 	)
 
 	cmd.PersistentFlags().StringVar(&configPath, "config", configPath, "config file path")
+	cmd.PersistentFlags().StringVar(&answersPath, "answers", "", "answer prompts from a toml, json or yaml file")
+	cmd.PersistentFlags().BoolVar(&promptRunner.Strict, "strict-answers", false, "fail on prompts without an answer instead of asking")
+	cmd.PersistentFlags().BoolVar(&promptRunner.NoInput, "no-input", false, "never prompt; use defaults or fail when input is missing")
 
 	initCmd := NewInitCmd(commandRunner, promptRunner, &configPath)
 	addCmd := NewAddCmd(commandRunner, promptRunner, &configPath)
@@ -102,22 +117,30 @@ It shortens common tasks like init, remove, and scaffold.`,
 	scaffoldCmd := NewScaffoldCmd(commandRunner, &configPath)
 	testCmd := NewTestCmd(commandRunner)
 	configCmd := NewConfigCmd(commandRunner, &configPath, promptRunner)
-	searchCmd := NewSearchCmd()
+	searchCmd := NewSearchCmd(commandRunner, promptRunner, searchBackend, &configPath)
 	installCmd := NewInstallCmd(commandRunner, promptRunner, &configPath)
-	uninstallCmd := NewUninstallCmd(commandRunner, promptRunner, &configPath)
+	uninstallCmd := NewUninstallCmd(promptRunner, &configPath)
 	installGlobalsCmd := NewInstallGlobalsCmd(commandRunner, &configPath)
+	outdatedCmd := NewOutdatedCmd(commandRunner, &configPath)
+	globalsCmd := NewGlobalsCmd(commandRunner, promptRunner, &configPath)
 	toolCmd := NewToolCmd(commandRunner, promptRunner, &configPath)
+	workspaceCmd := NewWorkspaceCmd(commandRunner)
+	cacheCmd := NewCacheCmd(&configPath)
 	initCmd.GroupID = "setup"
 	configCmd.GroupID = "setup"
+	cacheCmd.GroupID = "setup"
 	addCmd.GroupID = "local-packages"
 	removeCmd.GroupID = "local-packages"
 	installCmd.GroupID = "global-packages"
 	uninstallCmd.GroupID = "global-packages"
 	installGlobalsCmd.GroupID = "global-packages"
+	outdatedCmd.GroupID = "global-packages"
+	globalsCmd.GroupID = "global-packages"
 	toolCmd.GroupID = "tools"
 	scaffoldCmd.GroupID = "project"
 	testCmd.GroupID = "project"
 	searchCmd.GroupID = "project"
+	workspaceCmd.GroupID = "project"
 
 	cmd.AddCommand(
 		initCmd,
@@ -130,7 +153,11 @@ It shortens common tasks like init, remove, and scaffold.`,
 		installCmd,
 		uninstallCmd,
 		installGlobalsCmd,
+		outdatedCmd,
+		globalsCmd,
 		toolCmd,
+		workspaceCmd,
+		cacheCmd,
 	)
 
 	configureCompletions(cmd, scaffoldCmd, configCmd)
@@ -152,7 +179,8 @@ func Execute() error {
 func configureCompletions(root *cobra.Command, scaffoldCmd *cobra.Command, configCmd *cobra.Command) {
 	rootCarapace := carapace.Gen(root)
 	rootCarapace.FlagCompletion(carapace.ActionMap{
-		"config": carapace.ActionFiles(".toml"),
+		"config":  carapace.ActionFiles(".toml"),
+		"answers": carapace.ActionFiles(".toml", ".json", ".yaml", ".yml"),
 	})
 
 	carapace.Gen(scaffoldCmd).FlagCompletion(carapace.ActionMap{
@@ -173,6 +201,8 @@ func configureCompletions(root *cobra.Command, scaffoldCmd *cobra.Command, confi
 			)
 		case "provider":
 			configureProviderCompletions(command)
+		case "template":
+			configureTemplateCompletions(command)
 		}
 	}
 }
@@ -188,3 +218,15 @@ func configureProviderCompletions(providerCmd *cobra.Command) {
 		})
 	}
 }
+
+func configureTemplateCompletions(templateCmd *cobra.Command) {
+	for _, command := range templateCmd.Commands() {
+		if command.Name() != "add" {
+			continue
+		}
+
+		carapace.Gen(command).FlagCompletion(carapace.ActionMap{
+			"path": carapace.ActionDirectories(),
+		})
+	}
+}
@@ -22,11 +22,19 @@ func NewScaffoldCmd(commandRunner runner.Runner, configPath *string) *cobra.Comm
 	siteFlag := custom_flags.NewEmptyStringFlag("site")
 	userFlag := custom_flags.NewEmptyStringFlag("user")
 	var allowFull bool
+	var useWorkspace bool
 
 	cmd := &cobra.Command{
 		Use:   "scaffold <package name>",
 		Short: "Create a package folder with a root file",
 		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if useWorkspace && !initModule {
+				return custom_errors.CreateInvalidInputErrorWithMessage("--workspace requires --module")
+			}
+
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmdutil.LogInfoIfProduction("scaffold: loading config")
 			values, err := config.Load(*configPath)
@@ -41,6 +49,39 @@ func NewScaffoldCmd(commandRunner runner.Runner, configPath *string) *cobra.Comm
 			writeRootFile := true
 
 			folder = filepath.Clean(folder)
+
+			// Resolve everything the module steps need before writing files so
+			// a bad flag or a missing go.work leaves nothing behind.
+			var modulePath string
+			var workspaceArgs []string
+			if initModule {
+				site := config.ResolveSite(siteFlag.String(), values)
+				user, err := config.ResolveUser(userFlag.String(), values, site)
+				if err != nil {
+					if errors.Is(err, config.ErrMissingUser) {
+						return custom_errors.CreateInvalidInputErrorWithMessage("missing user; run go-toolkit config set-user <user>")
+					}
+					return err
+				}
+				allowCustomSite := allowFull || (siteFlag.String() == "" && values.Site != "")
+				if err := cmdutil.ValidateSite(site, allowCustomSite); err != nil {
+					return err
+				}
+
+				cmdutil.LogInfoIfProduction("scaffold: resolving module path for %s", site)
+				modulePath, err = packagepath.ResolveModulePath(packageName, site, user)
+				if err != nil {
+					return err
+				}
+
+				if useWorkspace {
+					workspaceArgs, err = workspaceUseArgs(folder)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
 			cmdutil.LogInfoIfProduction("scaffold: creating package at %s", folder)
 			if err := scaffold.Create(folder, scaffold.Options{
 				PackageName:   packageName,
@@ -56,31 +97,17 @@ func NewScaffoldCmd(commandRunner runner.Runner, configPath *string) *cobra.Comm
 				return nil
 			}
 
-			site := config.ResolveSite(siteFlag.String(), values)
-			user, err := config.ResolveUser(userFlag.String(), values, site)
-			if err != nil {
-				if errors.Is(err, config.ErrMissingUser) {
-					return custom_errors.CreateInvalidInputErrorWithMessage("missing user; run go-toolkit config set-user <user>")
-				}
-				return err
-			}
-			allowCustomSite := allowFull || (siteFlag.String() == "" && values.Site != "")
-			if err := cmdutil.ValidateSite(site, allowCustomSite); err != nil {
-				return err
-			}
-
-			cmdutil.LogInfoIfProduction("scaffold: resolving module path for %s", site)
-			modulePath, err := packagepath.ResolveModulePath(packageName, site, user)
-			if err != nil {
-				return err
-			}
-
 			cmdutil.LogInfoIfProduction("scaffold: running go mod init")
 			if err := commandRunner.Run(cmd, "go", "-C", folder, "mod", "init", modulePath); err != nil {
 				return err
 			}
 
-			return nil
+			if !useWorkspace {
+				return nil
+			}
+
+			cmdutil.LogInfoIfProduction("scaffold: adding module to go.work")
+			return commandRunner.Run(cmd, "go", workspaceArgs...)
 		},
 	}
 
@@ -90,6 +117,7 @@ func NewScaffoldCmd(commandRunner runner.Runner, configPath *string) *cobra.Comm
 	cmd.Flags().Var(&userFlag, "user", "override the configured user")
 	cmd.Flags().Var(&siteFlag, "site", "override the configured site")
 	cmd.Flags().BoolVar(&allowFull, "full", false, "allow a custom module site")
+	cmd.Flags().BoolVar(&useWorkspace, "workspace", false, "add the new module to the nearest go.work")
 	cmdutil.RegisterSiteCompletion(cmd, "site")
 
 	return cmd
@@ -57,4 +57,74 @@ var Scaffold = Describe("scaffold command", func() {
 		assert.NoError(err)
 		runner.AssertExpectations(GinkgoT())
 	})
+
+	It("adds the new module to the nearest workspace", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := testhelpers.CanonicalPath(GinkgoT().TempDir())
+		target := filepath.Join(tempDir, "services", "demo")
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\n"), 0o644)
+		assert.NoError(err)
+		err = os.WriteFile(filepath.Join(tempDir, "go.work"), []byte("go 1.24\n"), 0o644)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		runner.On("Run", mock.Anything, "go", []string{"-C", target, "mod", "init", "github.com/lou/demo"}).Return(nil).Once()
+		runner.On("Run", mock.Anything, "go", []string{"-C", tempDir, "work", "use", "./services/demo"}).Return(nil).Once()
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "scaffold", "demo", "--folder", target, "--module", "--workspace")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+	})
+
+	It("requires a workspace when --workspace is set", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		target := filepath.Join(tempDir, "demo")
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\n"), 0o644)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "scaffold", "demo", "--folder", target, "--module", "--workspace")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "no go.work found")
+		assert.NoDirExists(target)
+		runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("rejects --workspace without --module before writing files", func() {
+		tempDir := GinkgoT().TempDir()
+		target := filepath.Join(tempDir, "demo")
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\n"), 0o644)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "scaffold", "demo", "--folder", target, "--workspace")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "--workspace requires --module")
+		assert.NoDirExists(target)
+	})
 })
@@ -1,20 +1,46 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
 	"github.com/louiss0/go-toolkit/custom_errors"
+	"github.com/louiss0/go-toolkit/custom_flags"
 	"github.com/louiss0/go-toolkit/internal/cmdutil"
+	"github.com/louiss0/go-toolkit/internal/modindex/config"
+	"github.com/louiss0/go-toolkit/internal/modproxy"
+	"github.com/louiss0/go-toolkit/internal/packagepath"
+	"github.com/louiss0/go-toolkit/internal/prompt"
+	"github.com/louiss0/go-toolkit/internal/runner"
 	"github.com/louiss0/go-toolkit/internal/search"
 	"github.com/louiss0/go-toolkit/validation"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
-func NewSearchCmd() *cobra.Command {
+func NewSearchCmd(commandRunner runner.Runner, promptRunner prompt.Runner, backend search.Backend, configPath *string) *cobra.Command {
 
-	var modulePath string
+	var pathFlags *searchPathFlags
+	var query string
+	var keyword string
+	var filter search.Filter
+	var offline bool
+	var pick bool
+	var dryRun bool
 
 	cmd := &cobra.Command{
 		Use:   "search <query>",
-		Short: "List module versions from the Go proxy",
+		Short: "Find modules by keyword or list module versions from the Go proxy",
+		Long: `Find modules by keyword or list module versions from the Go proxy.
+
+A single word such as lo is a keyword search of recently published modules.
+Add a version query such as lo@latest to look the word up as a module under
+your user instead.
+
+` + modulePathHelp,
 		Args: func(cmd *cobra.Command, args []string) error {
 			argErr := cobra.ExactArgs(1)(cmd, args)
 
@@ -22,34 +48,385 @@ func NewSearchCmd() *cobra.Command {
 				return argErr
 			}
 
-			query := args[0]
-			if !validation.IsShortPackagePath(query) && !validation.IsFullModulePath(query) {
+			keyword = ""
+			query = args[0]
+			if search.IsKeyword(query) {
+				keyword = query
+				if filter.Latest || filter.Stable || filter.Major != "" || filter.Constraint != "" {
+					return custom_errors.CreateInvalidArgumentErrorWithMessage(
+						"--latest, --stable, --major and --constraint require a scope/package query",
+					)
+				}
+
+				return filter.Validate()
+			}
+
+			if !isModuleQuery(query) {
 				return custom_errors.CreateInvalidArgumentErrorWithMessage(
-					"query must be in the form scope/package or scope/package/vN",
+					"query must be a keyword or in the form scope/package or scope/package/vN, optionally followed by @version",
 				)
 			}
 
-			modulePath = search.ResolveModulePath(query)
-
-			return nil
+			return filter.Validate()
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cmdutil.LogInfoIfProduction("search: fetching module versions for %s", modulePath)
-			versions, err := search.FetchModuleVersions(cmd.Context(), modulePath)
-
+			values, err := config.Load(*configPath)
 			if err != nil {
 				return err
 			}
 
-			for _, version := range versions {
-				if err := cmdutil.WriteLine(cmd.OutOrStdout(), version); err != nil {
+			var entries []string
+			if keyword != "" {
+				cmdutil.LogInfoIfProduction("search: searching the module index for %s", keyword)
+				results, err := backend.Search(cmd.Context(), search.Query{
+					Text:     keyword,
+					Limit:    filter.Limit,
+					Offline:  offline,
+					CacheTTL: config.ResolveCacheTTL(values),
+				})
+				if err != nil {
+					return err
+				}
+				if len(results) == 0 {
+					return cmdutil.WriteLine(cmd.OutOrStdout(), noKeywordMatchMessage(backend, keyword))
+				}
+
+				results = lo.UniqBy(results, func(result search.Result) string {
+					return result.Path
+				})
+				entries = lo.Map(results, func(result search.Result, _ int) string {
+					return result.Path + "@" + result.Version
+				})
+			} else {
+				modulePath, versionQuery, err := pathFlags.resolve(cmd, values, query, offline)
+				if err != nil {
+					return err
+				}
+				versionFilter, err := search.ApplyVersionQuery(filter, versionQuery)
+				if err != nil {
+					return err
+				}
+
+				client, err := newSearchClient(values, offline)
+				if err != nil {
+					return err
+				}
+				cmdutil.LogInfoIfProduction("search: fetching module versions for %s from %s", modulePath, client)
+				entries, err = search.FetchModuleVersions(cmd.Context(), client, modulePath, versionFilter)
+				if err != nil {
 					return err
 				}
 			}
 
+			if !pick {
+				return cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(entries, "\n"))
+			}
+
+			selected, err := pickSearchEntries(cmd, promptRunner, entries, keyword != "")
+			if err != nil {
+				if errors.Is(err, huh.ErrUserAborted) {
+					return nil
+				}
+				return cmdutil.MissingInput(err, "--pick needs an interactive terminal; pass the printed module paths to add instead")
+			}
+			if len(selected) == 0 {
+				return cmdutil.WriteLine(cmd.OutOrStdout(), "no modules selected")
+			}
+
+			options := pathFlags.addOptions()
+			options.dryRun = dryRun
+			return addPackages(cmd, commandRunner, promptRunner, values, selected, options)
+		},
+	}
+
+	cmd.Flags().BoolVar(&filter.Latest, "latest", false, "print only the newest matching version")
+	cmd.Flags().BoolVar(&filter.Stable, "stable", false, "exclude prerelease versions")
+	cmd.Flags().StringVar(&filter.Major, "major", "", "only list versions for a major version such as v2")
+	cmd.Flags().StringVar(&filter.Constraint, "constraint", "", "only list versions matching a constraint such as \">=1.2 <2\"")
+	cmd.Flags().IntVar(&filter.Limit, "limit", 0, "print at most this many versions or modules")
+	cmd.Flags().BoolVar(&offline, "offline", false, "answer only from the local cache")
+	cmd.Flags().BoolVar(&pick, "pick", false, "choose results to add to the current module")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the go command for picked modules without running it")
+	pathFlags = addSearchPathFlags(cmd)
+	cmd.AddCommand(newSearchInfoCmd(configPath))
+
+	return cmd
+}
+
+type moduleInfoRequirement struct {
+	Path     string `json:"path"`
+	Version  string `json:"version"`
+	Indirect bool   `json:"indirect"`
+}
+
+type moduleInfoRetraction struct {
+	Low       string `json:"low"`
+	High      string `json:"high"`
+	Rationale string `json:"rationale,omitempty"`
+}
+
+type moduleInfoSummary struct {
+	Path        string                  `json:"path"`
+	Version     string                  `json:"version"`
+	Time        string                  `json:"time"`
+	GoVersion   string                  `json:"go,omitempty"`
+	Requires    []moduleInfoRequirement `json:"requires"`
+	Retractions []moduleInfoRetraction  `json:"retractions"`
+	Retracted   bool                    `json:"retracted"`
+	Deprecated  string                  `json:"deprecated,omitempty"`
+}
+
+func newSearchInfoCmd(configPath *string) *cobra.Command {
+	var pathFlags *searchPathFlags
+	var query string
+	var jsonOutput bool
+	var offline bool
+
+	cmd := &cobra.Command{
+		Use:   "info <module>[@version]",
+		Short: "Show module metadata from the Go proxy",
+		Args: func(cmd *cobra.Command, args []string) error {
+			argErr := cobra.ExactArgs(1)(cmd, args)
+
+			if argErr != nil {
+				return argErr
+			}
+
+			query = args[0]
+			if !isModuleQuery(query) {
+				return custom_errors.CreateInvalidArgumentErrorWithMessage(
+					"module must be in the form scope/package or scope/package/vN, optionally followed by @version",
+				)
+			}
+
 			return nil
 		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			values, err := config.Load(*configPath)
+			if err != nil {
+				return err
+			}
+
+			modulePath, version, err := pathFlags.resolve(cmd, values, query, offline)
+			if err != nil {
+				return err
+			}
+
+			client, err := newSearchClient(values, offline)
+			if err != nil {
+				return err
+			}
+			cmdutil.LogInfoIfProduction("search: fetching module metadata for %s from %s", modulePath, client)
+			info, err := search.FetchModuleInfo(cmd.Context(), client, modulePath, version)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return cmdutil.WritePrettyJSON(cmd.OutOrStdout(), buildModuleInfoSummary(info))
+			}
+
+			return cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(moduleInfoLines(info), "\n"))
+		},
 	}
 
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print module metadata as JSON")
+	cmd.Flags().BoolVar(&offline, "offline", false, "answer only from the local cache")
+	pathFlags = addSearchPathFlags(cmd)
+
 	return cmd
 }
+
+// searchPathFlags holds the --site, --user and --full flags that search
+// commands use to expand short module paths.
+type searchPathFlags struct {
+	site      fmt.Stringer
+	user      fmt.Stringer
+	allowFull bool
+}
+
+func addSearchPathFlags(cmd *cobra.Command) *searchPathFlags {
+	siteFlag := custom_flags.NewEmptyStringFlag("site")
+	userFlag := custom_flags.NewEmptyStringFlag("user")
+	flags := &searchPathFlags{site: &siteFlag, user: &userFlag}
+
+	cmd.Flags().Var(&userFlag, "user", "override the configured user")
+	cmd.Flags().Var(&siteFlag, "site", "override the configured site")
+	cmd.Flags().BoolVar(&flags.allowFull, "full", false, "allow a custom module site")
+	cmdutil.RegisterSiteCompletion(cmd, "site")
+
+	return flags
+}
+
+func (flags *searchPathFlags) resolve(cmd *cobra.Command, values config.Values, query string, offline bool) (string, string, error) {
+	return resolveSearchModulePath(cmd, values, query, flags.site.String(), flags.user.String(), flags.allowFull, offline)
+}
+
+// addOptions carries the flags over to add so picked modules resolve
+// against the same site and user the search did.
+func (flags *searchPathFlags) addOptions() addOptions {
+	return addOptions{
+		site:      flags.site.String(),
+		user:      flags.user.String(),
+		allowFull: flags.allowFull,
+	}
+}
+
+func resolveSearchModulePath(cmd *cobra.Command, values config.Values, query string, siteFlag string, userFlag string, allowFull bool, offline bool) (string, string, error) {
+	path, version, _ := strings.Cut(query, "@")
+
+	site := config.ResolveSite(siteFlag, values)
+	allowCustomSite := allowFull || (siteFlag == "" && values.Site != "")
+	if err := cmdutil.ValidateSite(site, allowCustomSite); err != nil {
+		return "", "", err
+	}
+
+	user := userFlag
+	if !strings.Contains(path, "/") {
+		resolvedUser, err := config.ResolveUser(userFlag, values, site)
+		if err != nil && !errors.Is(err, config.ErrMissingUser) {
+			return "", "", err
+		}
+		user = resolvedUser
+	}
+
+	modulePath, err := packagepath.ResolveModulePath(path, site, user)
+	if err != nil {
+		if errors.Is(err, packagepath.ErrMissingUser) {
+			return "", "", custom_errors.CreateInvalidInputErrorWithMessage("missing user; run go-toolkit config set-user <user>")
+		}
+		return "", "", err
+	}
+
+	host, _, _ := strings.Cut(modulePath, "/")
+	if offline || config.IsKnownSite(host) {
+		return modulePath, version, nil
+	}
+
+	cmdutil.LogInfoIfProduction("search: resolving the import path %s", modulePath)
+	root, err := search.ResolveImportPath(cmd.Context(), modulePath, search.FetchGoImportMeta)
+	if err != nil {
+		cmdutil.LogInfoIfProduction("search: %v; using %s as the module path", err, modulePath)
+		return modulePath, version, nil
+	}
+
+	return root, version, nil
+}
+
+func isModuleQuery(query string) bool {
+	path, _, hasVersion := strings.Cut(query, "@")
+	if hasVersion && search.IsKeyword(path) {
+		return true
+	}
+
+	return validation.IsShortPackagePath(path) || validation.IsFullModulePath(path)
+}
+
+func newSearchClient(values config.Values, offline bool) (modproxy.Client, error) {
+	store, err := openProxyCache(values)
+	if err != nil {
+		return modproxy.Client{}, err
+	}
+
+	return search.NewClient(search.ClientOptions{
+		Proxy:   values.Search.Proxy,
+		Cache:   store,
+		Offline: offline,
+	}), nil
+}
+
+func buildModuleInfoSummary(info search.ModuleInfo) moduleInfoSummary {
+	return moduleInfoSummary{
+		Path:      info.Path,
+		Version:   info.Version,
+		Time:      info.Time,
+		GoVersion: info.GoVersion,
+		Requires: lo.Map(info.Requires, func(require search.Requirement, _ int) moduleInfoRequirement {
+			return moduleInfoRequirement{Path: require.Path, Version: require.Version, Indirect: require.Indirect}
+		}),
+		Retractions: lo.Map(info.Retractions, func(retraction search.Retraction, _ int) moduleInfoRetraction {
+			return moduleInfoRetraction{Low: retraction.Low, High: retraction.High, Rationale: retraction.Rationale}
+		}),
+		Retracted:  info.Retracted,
+		Deprecated: info.Deprecated,
+	}
+}
+
+func moduleInfoLines(info search.ModuleInfo) []string {
+	lines := []string{info.Path + "@" + info.Version}
+	if info.Retracted {
+		lines[0] += " (retracted)"
+	}
+	if info.Time != "" {
+		lines = append(lines, "published: "+info.Time)
+	}
+	if info.GoVersion != "" {
+		lines = append(lines, "go: "+info.GoVersion)
+	}
+	if info.Deprecated != "" {
+		lines = append(lines, "deprecated: "+info.Deprecated)
+	}
+
+	if len(info.Requires) == 0 {
+		lines = append(lines, "requires: none")
+	} else {
+		lines = append(lines, "requires:")
+		for _, require := range info.Requires {
+			line := fmt.Sprintf("  %s %s", require.Path, require.Version)
+			if require.Indirect {
+				line += " // indirect"
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if len(info.Retractions) > 0 {
+		lines = append(lines, "retracted:")
+		for _, retraction := range info.Retractions {
+			line := "  " + retraction.Low
+			if retraction.High != retraction.Low {
+				line = fmt.Sprintf("  [%s, %s]", retraction.Low, retraction.High)
+			}
+			if retraction.Rationale != "" {
+				line += ": " + retraction.Rationale
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// pickSearchEntries offers one version per module: keyword results already
+// name distinct modules, while a version listing only allows a single choice.
+func pickSearchEntries(cmd *cobra.Command, promptRunner prompt.Runner, entries []string, multiple bool) ([]string, error) {
+	options := lo.Map(entries, func(entry string, _ int) prompt.Option {
+		return prompt.Option{Label: entry, Value: entry}
+	})
+	if multiple {
+		return promptRunner.MultiSelect(cmd, prompt.MultiSelect{Title: "Modules to add", Options: options})
+	}
+
+	selected, err := promptRunner.Select(cmd, prompt.Select{Title: "Version to add", Options: options})
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{selected}, nil
+}
+
+// noKeywordMatchMessage names the window the module index covers, since a
+// module that has not published recently is not in it.
+func noKeywordMatchMessage(backend search.Backend, keyword string) string {
+	message := fmt.Sprintf("no modules match %s", keyword)
+	reporter, ok := backend.(search.CoverageReporter)
+	if !ok || reporter.CoveredSince().IsZero() {
+		return message
+	}
+
+	return fmt.Sprintf(
+		"%s among modules published since %s; search scope/package to look up an older module directly",
+		message,
+		reporter.CoveredSince().Format(time.DateOnly),
+	)
+}
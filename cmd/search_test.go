@@ -1,16 +1,30 @@
 package cmd_test
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
 	"github.com/louiss0/go-toolkit/cmd"
+	"github.com/louiss0/go-toolkit/internal/modindex"
+	"github.com/louiss0/go-toolkit/internal/search"
+	"github.com/louiss0/go-toolkit/internal/testhelpers"
 	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var Search = Describe("search command", func() {
 	assert := assert.New(GinkgoT())
 
+	BeforeEach(func() {
+		GinkgoT().Setenv("XDG_CACHE_HOME", GinkgoT().TempDir())
+	})
+
 	It("accepts a scope and package query", func() {
-		searchCmd := cmd.NewSearchCmd()
+		searchCmd := cmd.NewSearchCmd(&testhelpers.RunnerMock{}, testhelpers.NewPromptRunnerMock(), search.MemoryBackend{}, new(string))
 
 		err := searchCmd.Args(searchCmd, []string{"acme/tool"})
 
@@ -18,7 +32,7 @@ var Search = Describe("search command", func() {
 	})
 
 	It("rejects missing query input", func() {
-		searchCmd := cmd.NewSearchCmd()
+		searchCmd := cmd.NewSearchCmd(&testhelpers.RunnerMock{}, testhelpers.NewPromptRunnerMock(), search.MemoryBackend{}, new(string))
 
 		err := searchCmd.Args(searchCmd, []string{})
 
@@ -26,17 +40,211 @@ var Search = Describe("search command", func() {
 		assert.Contains(err.Error(), "accepts 1 arg(s)")
 	})
 
-	It("rejects queries without a scope", func() {
-		searchCmd := cmd.NewSearchCmd()
+	It("accepts keyword queries", func() {
+		searchCmd := cmd.NewSearchCmd(&testhelpers.RunnerMock{}, testhelpers.NewPromptRunnerMock(), search.MemoryBackend{}, new(string))
 
 		err := searchCmd.Args(searchCmd, []string{"tool"})
 
+		assert.NoError(err)
+	})
+
+	It("rejects queries that are neither keywords nor module paths", func() {
+		searchCmd := cmd.NewSearchCmd(&testhelpers.RunnerMock{}, testhelpers.NewPromptRunnerMock(), search.MemoryBackend{}, new(string))
+
+		err := searchCmd.Args(searchCmd, []string{"acme tool"})
+
 		assert.Error(err)
 		assert.Contains(err.Error(), "scope/package")
 	})
 
+	It("rejects version filters for keyword queries", func() {
+		searchCmd := cmd.NewSearchCmd(&testhelpers.RunnerMock{}, testhelpers.NewPromptRunnerMock(), search.MemoryBackend{}, new(string))
+		err := searchCmd.Flags().Set("stable", "true")
+		assert.NoError(err)
+
+		err = searchCmd.Args(searchCmd, []string{"tool"})
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "require a scope/package query")
+	})
+
+	It("finds modules by keyword", func() {
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			SearchBackend: search.MemoryBackend{Modules: map[string]string{
+				"github.com/samber/lo":     "v1.49.1",
+				"github.com/samber/mo":     "v1.13.0",
+				"github.com/acme/lodash":   "v0.2.0",
+				"github.com/spf13/cobra":   "v1.10.2",
+				"github.com/acme/lo-utils": "v1.0.0",
+			}},
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "search", "lo", "--limit", "2")
+
+		assert.NoError(err)
+		assert.Equal("github.com/samber/lo@v1.49.1\ngithub.com/acme/lo-utils@v1.0.0\n", output)
+	})
+
+	Describe("pick", func() {
+		var configPath string
+		var backend search.MemoryBackend
+
+		BeforeEach(func() {
+			configPath = filepath.Join(GinkgoT().TempDir(), "config.toml")
+			err := os.WriteFile(configPath, []byte("site = \"github.com\"\n"), 0o644)
+			assert.NoError(err)
+
+			backend = search.MemoryBackend{Modules: map[string]string{
+				"github.com/samber/lo":   "v1.49.1",
+				"github.com/samber/mo":   "v1.13.0",
+				"github.com/spf13/cobra": "v1.10.2",
+			}}
+		})
+
+		It("adds the chosen modules with go get", func() {
+			runner := &testhelpers.RunnerMock{}
+			runner.On("Run", mock.Anything, "go", []string{"get", "github.com/samber/lo@v1.49.1", "github.com/samber/mo@v1.13.0"}).Return(nil).Once()
+
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner: runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(testhelpers.PromptStep{
+					Kind:   testhelpers.PromptStepMultiSelect,
+					Values: []string{"github.com/samber/lo@v1.49.1", "github.com/samber/mo@v1.13.0"},
+				}),
+				SearchBackend: backend,
+				ConfigPath:    configPath,
+			})
+
+			_, err := testhelpers.ExecuteCmd(rootCmd, "search", "samber", "--pick")
+
+			assert.NoError(err)
+			runner.AssertExpectations(GinkgoT())
+		})
+
+		It("picks a single version when listing versions", func() {
+			server := testhelpers.NewFakeProxy(map[string][]string{
+				"github.com/acme/tool": {"v1.0.0", "v1.1.0"},
+			})
+			DeferCleanup(server.Close)
+			err := os.WriteFile(configPath, []byte("site = \"github.com\"\n[search]\nproxy = \""+server.URL+"\"\n"), 0o644)
+			assert.NoError(err)
+
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner: &testhelpers.RunnerMock{},
+				PromptRunner: testhelpers.NewPromptRunnerMock(testhelpers.PromptStep{
+					Kind:  testhelpers.PromptStepSelect,
+					Value: "github.com/acme/tool@v1.0.0",
+				}),
+				ConfigPath: configPath,
+			})
+
+			output, err := testhelpers.ExecuteCmd(rootCmd, "search", "acme/tool", "--pick", "--dry-run")
+
+			assert.NoError(err)
+			assert.Equal("go get github.com/acme/tool@v1.0.0\n", output)
+		})
+
+		It("prints the go command for picked modules in dry run mode", func() {
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner: &testhelpers.RunnerMock{},
+				PromptRunner: testhelpers.NewPromptRunnerMock(testhelpers.PromptStep{
+					Kind:   testhelpers.PromptStepMultiSelect,
+					Values: []string{"github.com/spf13/cobra@v1.10.2"},
+				}),
+				SearchBackend: backend,
+				ConfigPath:    configPath,
+			})
+
+			output, err := testhelpers.ExecuteCmd(rootCmd, "search", "cobra", "--pick", "--dry-run")
+
+			assert.NoError(err)
+			assert.Equal("go get github.com/spf13/cobra@v1.10.2\n", output)
+		})
+
+		It("checks picked modules against the --site and --full flags", func() {
+			newRootCmd := func() *cobra.Command {
+				return cmd.NewRootCmdWithOptions(cmd.RootOptions{
+					Runner: &testhelpers.RunnerMock{},
+					PromptRunner: testhelpers.NewPromptRunnerMock(testhelpers.PromptStep{
+						Kind:   testhelpers.PromptStepMultiSelect,
+						Values: []string{"github.com/spf13/cobra@v1.10.2"},
+					}),
+					SearchBackend: backend,
+					ConfigPath:    configPath,
+				})
+			}
+
+			_, err := testhelpers.ExecuteCmd(newRootCmd(), "search", "cobra", "--pick", "--dry-run", "--site", "git.example.dev")
+
+			assert.Error(err)
+			assert.Contains(err.Error(), "unsupported site git.example.dev")
+
+			output, err := testhelpers.ExecuteCmd(newRootCmd(), "search", "cobra", "--pick", "--dry-run", "--site", "git.example.dev", "--full")
+
+			assert.NoError(err)
+			assert.Equal("go get github.com/spf13/cobra@v1.10.2\n", output)
+		})
+
+		It("does nothing when no modules are chosen", func() {
+			runner := &testhelpers.RunnerMock{}
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner: runner,
+				PromptRunner: testhelpers.NewPromptRunnerMock(testhelpers.PromptStep{
+					Kind: testhelpers.PromptStepMultiSelect,
+				}),
+				SearchBackend: backend,
+				ConfigPath:    configPath,
+			})
+
+			output, err := testhelpers.ExecuteCmd(rootCmd, "search", "cobra", "--pick")
+
+			assert.NoError(err)
+			assert.Equal("no modules selected\n", output)
+			runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything)
+		})
+	})
+
+	It("reports keywords without matches", func() {
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:        &testhelpers.RunnerMock{},
+			PromptRunner:  testhelpers.NewPromptRunnerMock(),
+			SearchBackend: search.MemoryBackend{},
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "search", "zzz")
+
+		assert.NoError(err)
+		assert.Equal("no modules match zzz\n", output)
+	})
+
+	It("names the window the module index covers when nothing matches", func() {
+		cachePath := filepath.Join(GinkgoT().TempDir(), "modindex.json")
+		start := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+		err := modindex.SaveIndex(cachePath, modindex.Index{
+			Start:     start,
+			Since:     start.Add(time.Hour),
+			FetchedAt: time.Now(),
+			CaughtUp:  true,
+			Modules:   map[string]string{"github.com/samber/lo": "v1.49.1"},
+		})
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:        &testhelpers.RunnerMock{},
+			PromptRunner:  testhelpers.NewPromptRunnerMock(),
+			SearchBackend: search.IndexBackend{CachePath: cachePath, MaxPages: modindex.DefaultMaxPages},
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "search", "cobra")
+
+		assert.NoError(err)
+		assert.Equal("no modules match cobra among modules published since 2026-10-10; search scope/package to look up an older module directly\n", output)
+	})
+
 	It("accepts queries with extra path segments", func() {
-		searchCmd := cmd.NewSearchCmd()
+		searchCmd := cmd.NewSearchCmd(&testhelpers.RunnerMock{}, testhelpers.NewPromptRunnerMock(), search.MemoryBackend{}, new(string))
 
 		err := searchCmd.Args(searchCmd, []string{"github.com/acme/tool"})
 
@@ -44,7 +252,7 @@ var Search = Describe("search command", func() {
 	})
 
 	It("accepts prefixed queries with multiple segments", func() {
-		searchCmd := cmd.NewSearchCmd()
+		searchCmd := cmd.NewSearchCmd(&testhelpers.RunnerMock{}, testhelpers.NewPromptRunnerMock(), search.MemoryBackend{}, new(string))
 
 		err := searchCmd.Args(searchCmd, []string{"github.com/acme/tool/extra"})
 
@@ -52,10 +260,244 @@ var Search = Describe("search command", func() {
 	})
 
 	It("accepts versioned short package queries", func() {
-		searchCmd := cmd.NewSearchCmd()
+		searchCmd := cmd.NewSearchCmd(&testhelpers.RunnerMock{}, testhelpers.NewPromptRunnerMock(), search.MemoryBackend{}, new(string))
 
 		err := searchCmd.Args(searchCmd, []string{"onsi/ginkgo/v2"})
 
 		assert.NoError(err)
 	})
+
+	It("lists versions from the configured proxy", func() {
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+		missingProxy := testhelpers.NewFakeProxy(map[string][]string{})
+		DeferCleanup(missingProxy.Close)
+		server := testhelpers.NewFakeProxy(map[string][]string{
+			"github.com/acme/tool": {"v1.0.0", "v1.1.0"},
+		})
+		DeferCleanup(server.Close)
+
+		err := os.WriteFile(configPath, []byte("[search]\nproxy = \""+missingProxy.URL+","+server.URL+"\"\n"), 0o644)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "search", "acme/tool")
+
+		assert.NoError(err)
+		assert.Equal("github.com/acme/tool@v1.1.0\ngithub.com/acme/tool@v1.0.0\n", output)
+	})
+
+	It("rejects malformed major filters", func() {
+		searchCmd := cmd.NewSearchCmd(&testhelpers.RunnerMock{}, testhelpers.NewPromptRunnerMock(), search.MemoryBackend{}, new(string))
+		err := searchCmd.Flags().Set("major", "two")
+		assert.NoError(err)
+
+		err = searchCmd.Args(searchCmd, []string{"acme/tool"})
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "major must be in the form vN")
+	})
+
+	It("prints the latest stable version", func() {
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+		server := testhelpers.NewFakeProxy(map[string][]string{
+			"github.com/acme/tool": {"v1.2.0", "v1.10.0", "v1.11.0-beta.1", "v1.9.0"},
+		})
+		DeferCleanup(server.Close)
+
+		err := os.WriteFile(configPath, []byte("[search]\nproxy = \""+server.URL+"\"\n"), 0o644)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		output, err := testhelpers.ExecuteCmd(rootCmd, "search", "acme/tool", "--latest", "--stable")
+
+		assert.NoError(err)
+		assert.Equal("github.com/acme/tool@v1.10.0\n", output)
+	})
+
+	Describe("module paths", func() {
+		var configPath string
+
+		BeforeEach(func() {
+			configPath = filepath.Join(GinkgoT().TempDir(), "config.toml")
+			server := testhelpers.NewFakeProxy(map[string][]string{
+				"github.com/acme/tool": {"v1.0.0", "v1.1.0", "v1.2.0"},
+				"gitlab.com/acme/tool": {"v0.4.0"},
+			})
+			DeferCleanup(server.Close)
+
+			err := os.WriteFile(configPath, []byte("[search]\nproxy = \""+server.URL+"\"\n"), 0o644)
+			assert.NoError(err)
+		})
+
+		DescribeTable("resolves queries like add",
+			func(args []string, expected string) {
+				rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+					Runner:       &testhelpers.RunnerMock{},
+					PromptRunner: testhelpers.NewPromptRunnerMock(),
+					ConfigPath:   configPath,
+				})
+
+				output, err := testhelpers.ExecuteCmd(rootCmd, append([]string{"search"}, args...)...)
+
+				assert.NoError(err)
+				assert.Equal(expected, output)
+			},
+			Entry("keeps full paths on other hosts", []string{"gitlab.com/acme/tool"}, "gitlab.com/acme/tool@v0.4.0\n"),
+			Entry("uses the site flag", []string{"acme/tool", "--site", "gitlab.com"}, "gitlab.com/acme/tool@v0.4.0\n"),
+			Entry("uses the user flag for bare package names", []string{"tool@latest", "--user", "acme"}, "github.com/acme/tool@v1.2.0\n"),
+			Entry("selects an exact version", []string{"acme/tool@v1.1.0"}, "github.com/acme/tool@v1.1.0\n"),
+			Entry("selects a version range", []string{"acme/tool@<v1.2"}, "github.com/acme/tool@v1.1.0\ngithub.com/acme/tool@v1.0.0\n"),
+		)
+
+		It("searches a bare word by keyword and looks it up under the user with a version", func() {
+			newRootCmd := func() *cobra.Command {
+				return cmd.NewRootCmdWithOptions(cmd.RootOptions{
+					Runner:        &testhelpers.RunnerMock{},
+					PromptRunner:  testhelpers.NewPromptRunnerMock(),
+					SearchBackend: search.MemoryBackend{Modules: map[string]string{"github.com/other/tool": "v0.1.0"}},
+					ConfigPath:    configPath,
+				})
+			}
+
+			output, err := testhelpers.ExecuteCmd(newRootCmd(), "search", "tool", "--user", "acme")
+
+			assert.NoError(err)
+			assert.Equal("github.com/other/tool@v0.1.0\n", output)
+
+			output, err = testhelpers.ExecuteCmd(newRootCmd(), "search", "tool@latest", "--user", "acme")
+
+			assert.NoError(err)
+			assert.Equal("github.com/acme/tool@v1.2.0\n", output)
+		})
+
+		It("rejects unknown sites without --full", func() {
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       &testhelpers.RunnerMock{},
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			_, err := testhelpers.ExecuteCmd(rootCmd, "search", "acme/tool", "--site", "git.example.com")
+
+			assert.Error(err)
+		})
+	})
+
+	It("answers from the cache when offline", func() {
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+		server := testhelpers.NewFakeProxy(map[string][]string{
+			"github.com/acme/tool": {"v1.0.0"},
+		})
+
+		err := os.WriteFile(configPath, []byte("[search]\nproxy = \""+server.URL+"\"\n"), 0o644)
+		assert.NoError(err)
+
+		newRootCmd := func() *cobra.Command {
+			return cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       &testhelpers.RunnerMock{},
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+		}
+
+		_, err = testhelpers.ExecuteCmd(newRootCmd(), "search", "acme/tool")
+		assert.NoError(err)
+		server.Close()
+
+		output, err := testhelpers.ExecuteCmd(newRootCmd(), "search", "acme/tool", "--offline")
+		assert.NoError(err)
+		assert.Equal("github.com/acme/tool@v1.0.0\n", output)
+
+		_, err = testhelpers.ExecuteCmd(newRootCmd(), "search", "acme/other", "--offline")
+		assert.Error(err)
+		assert.Contains(err.Error(), "module github.com/acme/other is not cached")
+	})
+
+	It("reports modules missing from every proxy", func() {
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+		server := testhelpers.NewFakeProxy(map[string][]string{})
+		DeferCleanup(server.Close)
+
+		err := os.WriteFile(configPath, []byte("[search]\nproxy = \""+server.URL+"\"\n"), 0o644)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "search", "acme/tool")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "module github.com/acme/tool was not found on "+server.URL)
+	})
+
+	Describe("info", func() {
+		var configPath string
+
+		BeforeEach(func() {
+			configPath = filepath.Join(GinkgoT().TempDir(), "config.toml")
+			server := testhelpers.NewFakeProxyWithMods(map[string][]string{
+				"github.com/acme/tool": {"v1.0.0", "v1.1.0"},
+			}, map[string]string{
+				"github.com/acme/tool@v1.1.0": "module github.com/acme/tool\n\ngo 1.22\n\nrequire github.com/samber/lo v1.49.1\n\nretract v1.0.0 // broken\n",
+			})
+			DeferCleanup(server.Close)
+
+			err := os.WriteFile(configPath, []byte("[search]\nproxy = \""+server.URL+"\"\n"), 0o644)
+			assert.NoError(err)
+		})
+
+		It("prints module metadata", func() {
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       &testhelpers.RunnerMock{},
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			output, err := testhelpers.ExecuteCmd(rootCmd, "search", "info", "acme/tool")
+
+			assert.NoError(err)
+			assert.Equal(`github.com/acme/tool@v1.1.0
+published: 2025-01-01T00:00:00Z
+go: 1.22
+requires:
+  github.com/samber/lo v1.49.1
+retracted:
+  v1.0.0: broken
+`, output)
+		})
+
+		It("prints metadata for a version as JSON", func() {
+			rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+				Runner:       &testhelpers.RunnerMock{},
+				PromptRunner: testhelpers.NewPromptRunnerMock(),
+				ConfigPath:   configPath,
+			})
+
+			output, err := testhelpers.ExecuteCmd(rootCmd, "search", "info", "acme/tool@v1.0.0", "--json")
+
+			assert.NoError(err)
+			var summary map[string]any
+			assert.NoError(json.Unmarshal([]byte(output), &summary))
+			assert.Equal("v1.0.0", summary["version"])
+			assert.Equal(true, summary["retracted"])
+			assert.Equal([]any{}, summary["requires"])
+		})
+	})
 })
@@ -25,7 +25,7 @@ func NewToolCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configP
 
 	cmd.AddCommand(
 		NewToolAddCmd(commandRunner, promptRunner, configPath),
-		NewToolRemoveCmd(commandRunner, promptRunner, configPath),
+		NewToolRemoveCmd(promptRunner, configPath),
 	)
 
 	return cmd
@@ -53,7 +53,7 @@ func NewToolAddCmd(commandRunner runner.Runner, promptRunner prompt.Runner, conf
 					if errors.Is(err, huh.ErrUserAborted) {
 						return nil
 					}
-					return err
+					return cmdutil.MissingInput(err, "missing tools; pass tool names as arguments")
 				}
 				targetTools = inputs
 			}
@@ -65,10 +65,13 @@ func NewToolAddCmd(commandRunner runner.Runner, promptRunner prompt.Runner, conf
 				return err
 			}
 
-			modulePaths := resolveToolModulePaths(targetTools)
-			installArgs := lo.Map(modulePaths, func(modulePath string, _ int) string {
-				return modulePath + "@latest"
+			globalPackages := lo.Map(resolveToolModulePaths(targetTools), func(modulePath string, _ int) config.GlobalPackage {
+				return config.ParseGlobalPackage(modulePath)
 			})
+			installArgs, err := resolveGlobalInstallArgs(cmd, values, globalPackages)
+			if err != nil {
+				return err
+			}
 
 			if dryRun {
 				lines := lo.Map(installArgs, func(arg string, _ int) string {
@@ -83,10 +86,13 @@ func NewToolAddCmd(commandRunner runner.Runner, promptRunner prompt.Runner, conf
 				}
 			}
 
-			values.GlobalPackages = lo.Uniq(append(values.GlobalPackages, modulePaths...))
+			values.GlobalPackages = config.UpsertGlobalPackages(values.GlobalPackages, globalPackages...)
 			if err := config.Save(*configPath, values); err != nil {
 				return err
 			}
+			if err := recordGlobalLock(cmd, values, *configPath, installArgs, nil); err != nil {
+				return err
+			}
 
 			return cmdutil.WriteLine(cmd.OutOrStdout(), "tools added and saved to global packages")
 		},
@@ -97,7 +103,7 @@ func NewToolAddCmd(commandRunner runner.Runner, promptRunner prompt.Runner, conf
 	return cmd
 }
 
-func NewToolRemoveCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configPath *string) *cobra.Command {
+func NewToolRemoveCmd(promptRunner prompt.Runner, configPath *string) *cobra.Command {
 	var dryRun bool
 
 	cmd := &cobra.Command{
@@ -119,7 +125,7 @@ func NewToolRemoveCmd(commandRunner runner.Runner, promptRunner prompt.Runner, c
 					if errors.Is(err, huh.ErrUserAborted) {
 						return nil
 					}
-					return err
+					return cmdutil.MissingInput(err, "missing tools; pass tool names as arguments")
 				}
 				targetTools = inputs
 			}
@@ -131,29 +137,25 @@ func NewToolRemoveCmd(commandRunner runner.Runner, promptRunner prompt.Runner, c
 				return err
 			}
 
-			modulePaths := resolveToolModulePaths(targetTools)
+			modulePaths := lo.Map(resolveToolModulePaths(targetTools), func(modulePath string, _ int) string {
+				return config.ParseGlobalPackage(modulePath).Path
+			})
 			if dryRun {
 				lines := lo.Map(modulePaths, func(modulePath string, _ int) string {
-					return "go clean -i " + modulePath
+					return "rm " + globalBinaryPath(modulePath)
 				})
 				return cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(lines, "\n"))
 			}
 
-			for _, modulePath := range modulePaths {
-				if err := commandRunner.Run(cmd, "go", "clean", "-i", modulePath); err != nil {
-					return err
+			removed, removeErr := removeGlobalBinaries(*configPath, modulePaths)
+			if len(removed) > 0 {
+				values.GlobalPackages = config.RemoveGlobalPackages(values.GlobalPackages, removed)
+				if err := config.Save(*configPath, values); err != nil {
+					return errors.Join(removeErr, err)
 				}
 			}
-
-			removeSet := lo.SliceToMap(modulePaths, func(modulePath string) (string, struct{}) {
-				return modulePath, struct{}{}
-			})
-			values.GlobalPackages = lo.Filter(values.GlobalPackages, func(modulePath string, _ int) bool {
-				_, shouldRemove := removeSet[modulePath]
-				return !shouldRemove
-			})
-			if err := config.Save(*configPath, values); err != nil {
-				return err
+			if removeErr != nil {
+				return removeErr
 			}
 
 			return cmdutil.WriteLine(cmd.OutOrStdout(), "tools removed from global packages")
@@ -189,9 +191,10 @@ func validateToolInputs(inputs []string) error {
 	}
 
 	if lo.ContainsBy(trimmedTools, func(input string) bool {
-		return !validation.IsToolName(input) && !validation.IsToolPath(input)
+		toolPath := config.ParseGlobalPackage(input).Path
+		return !validation.IsToolName(toolPath) && !validation.IsToolPath(toolPath)
 	}) {
-		return custom_errors.CreateInvalidInputErrorWithMessage("tools must be bare names like goimports or slash-separated paths")
+		return custom_errors.CreateInvalidInputErrorWithMessage("tools must be bare names like goimports or slash-separated paths, optionally followed by @version")
 	}
 
 	return nil
@@ -200,7 +203,7 @@ func validateToolInputs(inputs []string) error {
 func resolveToolModulePaths(tools []string) []string {
 	return lo.Uniq(lo.Map(tools, func(tool string, _ int) string {
 		trimmedTool := strings.TrimSpace(tool)
-		if validation.IsToolPath(trimmedTool) {
+		if validation.IsToolPath(config.ParseGlobalPackage(trimmedTool).Path) {
 			return trimmedTool
 		}
 
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/louiss0/go-toolkit/cmd"
+	"github.com/louiss0/go-toolkit/internal/globals"
 	"github.com/louiss0/go-toolkit/internal/modindex/config"
 	"github.com/louiss0/go-toolkit/internal/testhelpers"
 	. "github.com/onsi/ginkgo/v2"
@@ -15,6 +16,13 @@ import (
 var Tool = Describe("tool command", func() {
 	assert := assert.New(GinkgoT())
 
+	var binDir string
+
+	BeforeEach(func() {
+		binDir = GinkgoT().TempDir()
+		GinkgoT().Setenv("GOBIN", binDir)
+	})
+
 	It("installs a tool from the x tools cmd path", func() {
 		runner := &testhelpers.RunnerMock{}
 		tempDir := GinkgoT().TempDir()
@@ -39,7 +47,33 @@ var Tool = Describe("tool command", func() {
 
 		values, err := config.Load(configPath)
 		assert.NoError(err)
-		assert.Contains(values.GlobalPackages, "golang.org/x/tools/cmd/goimports")
+		assert.Contains(config.GlobalPackagePaths(values.GlobalPackages), "golang.org/x/tools/cmd/goimports")
+	})
+
+	It("installs and records a pinned tool version", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := writeDefaultConfig(configPath)
+		assert.NoError(err)
+
+		runner.On("Run", mock.Anything, "go", []string{"install", "golang.org/x/tools/cmd/goimports@v0.21.0"}).Return(nil).Once()
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "tool", "add", "goimports@v0.21.0")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Equal([]config.GlobalPackage{{Path: "golang.org/x/tools/cmd/goimports", Version: "v0.21.0"}}, values.GlobalPackages)
 	})
 
 	It("prints the tool install command on dry run", func() {
@@ -86,7 +120,7 @@ var Tool = Describe("tool command", func() {
 
 		values, err := config.Load(configPath)
 		assert.NoError(err)
-		assert.Contains(values.GlobalPackages, "mvdan.cc/gofumpt")
+		assert.Contains(config.GlobalPackagePaths(values.GlobalPackages), "mvdan.cc/gofumpt")
 	})
 
 	It("uninstalls a tool from the x tools cmd path", func() {
@@ -97,7 +131,9 @@ var Tool = Describe("tool command", func() {
 		err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\nglobal_packages = [\"golang.org/x/tools/cmd/goimports\"]\n"), 0o644)
 		assert.NoError(err)
 
-		runner.On("Run", mock.Anything, "go", []string{"clean", "-i", "golang.org/x/tools/cmd/goimports"}).Return(nil).Once()
+		binaryPath := filepath.Join(binDir, globals.BinaryName("golang.org/x/tools/cmd/goimports"))
+		err = testhelpers.BuildGoBinary(binaryPath, "golang.org/x/tools/cmd/goimports")
+		assert.NoError(err)
 
 		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
 			Runner:       runner,
@@ -109,7 +145,8 @@ var Tool = Describe("tool command", func() {
 
 		assert.NoError(err)
 		assert.Contains(output, "tools removed from global packages")
-		runner.AssertExpectations(GinkgoT())
+		runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything)
+		assert.NoFileExists(binaryPath)
 
 		values, err := config.Load(configPath)
 		assert.NoError(err)
@@ -10,12 +10,11 @@ import (
 	"github.com/louiss0/go-toolkit/internal/cmdutil"
 	"github.com/louiss0/go-toolkit/internal/modindex/config"
 	"github.com/louiss0/go-toolkit/internal/prompt"
-	"github.com/louiss0/go-toolkit/internal/runner"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
-func NewUninstallCmd(commandRunner runner.Runner, promptRunner prompt.Runner, configPath *string) *cobra.Command {
+func NewUninstallCmd(promptRunner prompt.Runner, configPath *string) *cobra.Command {
 	siteFlag := custom_flags.NewEmptyStringFlag("site")
 	userFlag := custom_flags.NewEmptyStringFlag("user")
 	var allowFull bool
@@ -26,6 +25,7 @@ func NewUninstallCmd(commandRunner runner.Runner, promptRunner prompt.Runner, co
 	cmd := &cobra.Command{
 		Use:   "uninstall [package] [packages...]",
 		Short: "Uninstall Go binaries globally and remove them from the global package list",
+		Long:  "Uninstall Go binaries globally and remove them from the global package list.\n\n" + modulePathHelp,
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validateInstallInputs(args)
 		},
@@ -35,19 +35,18 @@ func NewUninstallCmd(commandRunner runner.Runner, promptRunner prompt.Runner, co
 				return err
 			}
 
-			promptPackages := []string(nil)
+			selection := packageSelection{}
 			if len(args) == 0 && len(packageFlags) == 0 && len(presetFlags) == 0 {
-				inputs, err := promptInstallPackages(cmd, promptRunner)
+				selection, err = promptUninstallPackages(cmd, promptRunner, values)
 				if err != nil {
 					if errors.Is(err, huh.ErrUserAborted) {
 						return nil
 					}
-					return err
+					return cmdutil.MissingInput(err, "missing packages; pass them as arguments or use --package or --preset")
 				}
-				promptPackages = inputs
 			}
 
-			installPackages, err := resolveInstallPackages(values, packageFlags, presetFlags, promptPackages)
+			installPackages, err := resolveInstallPackages(values, packageFlags, append(presetFlags, selection.Presets...), selection.Packages)
 			if err != nil {
 				return err
 			}
@@ -95,27 +94,21 @@ func NewUninstallCmd(commandRunner runner.Runner, promptRunner prompt.Runner, co
 			if dryRun {
 				cmdutil.LogInfoIfProduction("uninstall: dry run output")
 				lines := lo.Map(basePaths, func(modulePath string, _ int) string {
-					return "go clean -i " + modulePath
+					return "rm " + globalBinaryPath(modulePath)
 				})
 				return cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(lines, "\n"))
 			}
 
-			cmdutil.LogInfoIfProduction("uninstall: executing go clean -i")
-			for _, modulePath := range basePaths {
-				if err := commandRunner.Run(cmd, "go", "clean", "-i", modulePath); err != nil {
-					return err
+			cmdutil.LogInfoIfProduction("uninstall: removing installed binaries")
+			removed, removeErr := removeGlobalBinaries(*configPath, basePaths)
+			if len(removed) > 0 {
+				values.GlobalPackages = config.RemoveGlobalPackages(values.GlobalPackages, removed)
+				if err := config.Save(*configPath, values); err != nil {
+					return errors.Join(removeErr, err)
 				}
 			}
-
-			removeSet := lo.SliceToMap(basePaths, func(modulePath string) (string, struct{}) {
-				return modulePath, struct{}{}
-			})
-			values.GlobalPackages = lo.Filter(values.GlobalPackages, func(modulePath string, _ int) bool {
-				_, shouldRemove := removeSet[modulePath]
-				return !shouldRemove
-			})
-			if err := config.Save(*configPath, values); err != nil {
-				return err
+			if removeErr != nil {
+				return removeErr
 			}
 
 			return cmdutil.WriteLine(cmd.OutOrStdout(), "uninstalled and removed from global packages")
@@ -132,3 +125,21 @@ func NewUninstallCmd(commandRunner runner.Runner, promptRunner prompt.Runner, co
 
 	return cmd
 }
+
+func promptUninstallPackages(cmd *cobra.Command, runner prompt.Runner, values config.Values) (packageSelection, error) {
+	if len(values.GlobalPackages) == 0 {
+		return promptPackageSelection(cmd, runner, values, "Packages to uninstall", "packages to uninstall")
+	}
+
+	packages, err := runner.MultiSelect(cmd, prompt.MultiSelect{
+		Title: "Packages to uninstall",
+		Options: lo.Map(values.GlobalPackages, func(globalPackage config.GlobalPackage, _ int) prompt.Option {
+			return prompt.Option{Label: globalPackage.String(), Value: globalPackage.Path}
+		}),
+	})
+	if err != nil {
+		return packageSelection{}, err
+	}
+
+	return packageSelection{Packages: packages}, nil
+}
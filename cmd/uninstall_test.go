@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/louiss0/go-toolkit/cmd"
+	"github.com/louiss0/go-toolkit/internal/globals"
 	"github.com/louiss0/go-toolkit/internal/modindex/config"
 	"github.com/louiss0/go-toolkit/internal/testhelpers"
 	. "github.com/onsi/ginkgo/v2"
@@ -15,6 +16,13 @@ import (
 var Uninstall = Describe("uninstall command", func() {
 	assert := assert.New(GinkgoT())
 
+	var binDir string
+
+	BeforeEach(func() {
+		binDir = GinkgoT().TempDir()
+		GinkgoT().Setenv("GOBIN", binDir)
+	})
+
 	It("uninstalls a package globally and removes it from config", func() {
 		runner := &testhelpers.RunnerMock{}
 		tempDir := GinkgoT().TempDir()
@@ -23,7 +31,9 @@ var Uninstall = Describe("uninstall command", func() {
 		err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\nglobal_packages = [\"github.com/onsi/ginkgo/v2\"]\n"), 0o644)
 		assert.NoError(err)
 
-		runner.On("Run", mock.Anything, "go", []string{"clean", "-i", "github.com/onsi/ginkgo/v2"}).Return(nil).Once()
+		binaryPath := filepath.Join(binDir, globals.BinaryName("github.com/onsi/ginkgo/v2"))
+		err = testhelpers.BuildGoBinary(binaryPath, "github.com/onsi/ginkgo/v2")
+		assert.NoError(err)
 
 		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
 			Runner:       runner,
@@ -35,13 +45,86 @@ var Uninstall = Describe("uninstall command", func() {
 
 		assert.NoError(err)
 		assert.Contains(output, "uninstalled and removed from global packages")
-		runner.AssertExpectations(GinkgoT())
+		runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything)
+		assert.NoFileExists(binaryPath)
 
 		values, err := config.Load(configPath)
 		assert.NoError(err)
 		assert.Empty(values.GlobalPackages)
 	})
 
+	It("keeps a binary that another package installed", func() {
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\nglobal_packages = [\"github.com/onsi/ginkgo/v2\"]\n"), 0o644)
+		assert.NoError(err)
+
+		binaryPath := filepath.Join(binDir, globals.BinaryName("github.com/onsi/ginkgo/v2"))
+		err = os.WriteFile(binaryPath, []byte("binary"), 0o755)
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "uninstall", "github.com/onsi/ginkgo/v2")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "is not a Go binary built from github.com/onsi/ginkgo/v2")
+		assert.FileExists(binaryPath)
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Equal([]string{"github.com/onsi/ginkgo/v2"}, config.GlobalPackagePaths(values.GlobalPackages))
+	})
+
+	It("removes the other binaries and updates config and lock when one conflicts", func() {
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\nglobal_packages = [\"github.com/onsi/ginkgo/v2\", \"github.com/samber/lo\"]\n"), 0o644)
+		assert.NoError(err)
+		err = globals.SaveLock(globals.LockPath(configPath), globals.Lock{Packages: []globals.Entry{
+			{Path: "github.com/onsi/ginkgo/v2", Version: "v2.22.0", Binary: "ginkgo"},
+			{Path: "github.com/samber/lo", Version: "v1.49.1", Binary: "lo"},
+		}})
+		assert.NoError(err)
+
+		conflictPath := filepath.Join(binDir, globals.BinaryName("github.com/onsi/ginkgo/v2"))
+		err = os.WriteFile(conflictPath, []byte("binary"), 0o755)
+		assert.NoError(err)
+		binaryPath := filepath.Join(binDir, globals.BinaryName("github.com/samber/lo"))
+		err = testhelpers.BuildGoBinary(binaryPath, "github.com/samber/lo")
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "uninstall", "github.com/onsi/ginkgo/v2", "github.com/samber/lo")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "is not a Go binary built from github.com/onsi/ginkgo/v2")
+		assert.FileExists(conflictPath)
+		assert.NoFileExists(binaryPath)
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Equal([]string{"github.com/onsi/ginkgo/v2"}, config.GlobalPackagePaths(values.GlobalPackages))
+
+		lock, err := globals.LoadLock(globals.LockPath(configPath))
+		assert.NoError(err)
+		_, found := lock.Find("github.com/samber/lo")
+		assert.False(found)
+		_, found = lock.Find("github.com/onsi/ginkgo/v2")
+		assert.True(found)
+	})
+
 	It("prints the uninstall command on dry run", func() {
 		runner := &testhelpers.RunnerMock{}
 		tempDir := GinkgoT().TempDir()
@@ -60,7 +143,33 @@ var Uninstall = Describe("uninstall command", func() {
 
 		assert.NoError(err)
 		runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything)
-		assert.Contains(output, "go clean -i github.com/onsi/ginkgo/v2")
+		assert.Contains(output, "rm "+filepath.Join(binDir, globals.BinaryName("github.com/onsi/ginkgo/v2")))
+	})
+
+	It("uninstalls two element paths on a dotted host as full module paths", func() {
+		configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+		err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\nglobal_packages = [\"mvdan.cc/gofumpt\"]\n"), 0o644)
+		assert.NoError(err)
+
+		binaryPath := filepath.Join(binDir, globals.BinaryName("mvdan.cc/gofumpt"))
+		err = testhelpers.BuildGoBinary(binaryPath, "mvdan.cc/gofumpt")
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       &testhelpers.RunnerMock{},
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "uninstall", "mvdan.cc/gofumpt")
+
+		assert.NoError(err)
+		assert.NoFileExists(binaryPath)
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Empty(values.GlobalPackages)
 	})
 
 	It("uninstalls a short package path with a major version suffix", func() {
@@ -71,8 +180,6 @@ var Uninstall = Describe("uninstall command", func() {
 		err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\nglobal_packages = [\"github.com/onsi/ginkgo/v2\"]\n"), 0o644)
 		assert.NoError(err)
 
-		runner.On("Run", mock.Anything, "go", []string{"clean", "-i", "github.com/onsi/ginkgo/v2"}).Return(nil).Once()
-
 		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
 			Runner:       runner,
 			PromptRunner: testhelpers.NewPromptRunnerMock(),
@@ -82,6 +189,39 @@ var Uninstall = Describe("uninstall command", func() {
 		_, err = testhelpers.ExecuteCmd(rootCmd, "uninstall", "onsi/ginkgo/v2")
 
 		assert.NoError(err)
-		runner.AssertExpectations(GinkgoT())
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Empty(values.GlobalPackages)
+	})
+
+	It("prompts with the saved global packages", func() {
+		runner := &testhelpers.RunnerMock{}
+		tempDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tempDir, "config.toml")
+
+		err := os.WriteFile(configPath, []byte("user = \"lou\"\nsite = \"github.com\"\nglobal_packages = [\"github.com/onsi/ginkgo/v2\", \"github.com/samber/lo\"]\n"), 0o644)
+		assert.NoError(err)
+
+		binaryPath := filepath.Join(binDir, globals.BinaryName("github.com/samber/lo"))
+		err = testhelpers.BuildGoBinary(binaryPath, "github.com/samber/lo")
+		assert.NoError(err)
+
+		rootCmd := cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner: runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(
+				testhelpers.PromptStep{Kind: testhelpers.PromptStepMultiSelect, Values: []string{"github.com/samber/lo"}},
+			),
+			ConfigPath: configPath,
+		})
+
+		_, err = testhelpers.ExecuteCmd(rootCmd, "uninstall")
+
+		assert.NoError(err)
+		assert.NoFileExists(binaryPath)
+
+		values, err := config.Load(configPath)
+		assert.NoError(err)
+		assert.Equal([]string{"github.com/onsi/ginkgo/v2"}, config.GlobalPackagePaths(values.GlobalPackages))
 	})
 })
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/louiss0/go-toolkit/custom_errors"
+	"github.com/louiss0/go-toolkit/internal/cmdutil"
+	"github.com/louiss0/go-toolkit/internal/gomod"
+	"github.com/louiss0/go-toolkit/internal/runner"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+func NewWorkspaceCmd(commandRunner runner.Runner) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Create and maintain a go.work workspace",
+	}
+
+	cmd.AddCommand(
+		NewWorkspaceInitCmd(commandRunner),
+		NewWorkspaceUseCmd(commandRunner),
+		NewWorkspaceDropCmd(commandRunner),
+		NewWorkspaceSyncCmd(commandRunner),
+	)
+
+	return cmd
+}
+
+func NewWorkspaceInitCmd(commandRunner runner.Runner) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "init [module dirs...]",
+		Short: "Create a go.work in the current folder",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if workFile, err := gomod.FindWorkFile("."); err == nil {
+				return custom_errors.CreateInvalidInputErrorWithMessage(
+					fmt.Sprintf("workspace already exists at %s", workFile),
+				)
+			} else if !errors.Is(err, gomod.ErrWorkFileNotFound) {
+				return err
+			}
+
+			goArgs := append([]string{"work", "init"}, cleanWorkspaceDirs(args)...)
+			return runWorkspaceCommands(cmd, commandRunner, dryRun, "workspace init", [][]string{goArgs})
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the go command without running it")
+
+	return cmd
+}
+
+func NewWorkspaceUseCmd(commandRunner runner.Runner) *cobra.Command {
+	var dryRun bool
+	var recursive bool
+
+	cmd := &cobra.Command{
+		Use:   "use <module dir> [module dirs...]",
+		Short: "Add modules to the nearest go.work",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := findWorkspace("."); err != nil {
+				return err
+			}
+
+			goArgs := []string{"work", "use"}
+			if recursive {
+				goArgs = append(goArgs, "-r")
+			}
+			goArgs = append(goArgs, cleanWorkspaceDirs(args)...)
+
+			return runWorkspaceCommands(cmd, commandRunner, dryRun, "workspace use", [][]string{goArgs})
+		},
+	}
+
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "add every module found under the given folders")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the go command without running it")
+
+	return cmd
+}
+
+func NewWorkspaceDropCmd(commandRunner runner.Runner) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "drop <module dir> [module dirs...]",
+		Short: "Remove modules from the nearest go.work",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := findWorkspace("."); err != nil {
+				return err
+			}
+
+			goArgs := append([]string{"work", "edit"}, lo.Map(cleanWorkspaceDirs(args), func(dir string, _ int) string {
+				return "-dropuse=" + dir
+			})...)
+
+			return runWorkspaceCommands(cmd, commandRunner, dryRun, "workspace drop", [][]string{goArgs})
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the go command without running it")
+
+	return cmd
+}
+
+func NewWorkspaceSyncCmd(commandRunner runner.Runner) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Add every module under the workspace root and sync dependencies",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workRoot, err := findWorkspace(".")
+			if err != nil {
+				return err
+			}
+
+			return runWorkspaceCommands(cmd, commandRunner, dryRun, "workspace sync", [][]string{
+				{"-C", workRoot, "work", "use", "-r", "."},
+				{"-C", workRoot, "work", "sync"},
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the go commands without running them")
+
+	return cmd
+}
+
+func runWorkspaceCommands(cmd *cobra.Command, commandRunner runner.Runner, dryRun bool, logPrefix string, commands [][]string) error {
+	if dryRun {
+		cmdutil.LogInfoIfProduction("%s: dry run output", logPrefix)
+		lines := lo.Map(commands, func(args []string, _ int) string {
+			return "go " + strings.Join(args, " ")
+		})
+		return cmdutil.WriteLine(cmd.OutOrStdout(), strings.Join(lines, "\n"))
+	}
+
+	for _, args := range commands {
+		cmdutil.LogInfoIfProduction("%s: running go %s", logPrefix, strings.Join(args, " "))
+		if err := commandRunner.Run(cmd, "go", args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func findWorkspace(dir string) (string, error) {
+	workFile, err := gomod.FindWorkFile(dir)
+	if err != nil {
+		if errors.Is(err, gomod.ErrWorkFileNotFound) {
+			return "", custom_errors.CreateInvalidInputErrorWithMessage("no go.work found; run go-toolkit workspace init")
+		}
+		return "", err
+	}
+
+	return filepath.Dir(workFile), nil
+}
+
+func workspaceUseArgs(moduleDir string) ([]string, error) {
+	absModuleDir, err := filepath.Abs(moduleDir)
+	if err != nil {
+		return nil, err
+	}
+
+	workRoot, err := findWorkspace(absModuleDir)
+	if err != nil {
+		return nil, err
+	}
+
+	relativeDir, err := filepath.Rel(workRoot, absModuleDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{"-C", workRoot, "work", "use", workspaceDir(relativeDir)}, nil
+}
+
+func cleanWorkspaceDirs(dirs []string) []string {
+	return lo.Map(dirs, func(dir string, _ int) string {
+		return workspaceDir(filepath.Clean(dir))
+	})
+}
+
+func workspaceDir(dir string) string {
+	slashDir := filepath.ToSlash(dir)
+	if slashDir == "." || strings.HasPrefix(slashDir, "./") || strings.HasPrefix(slashDir, "../") || filepath.IsAbs(dir) {
+		return slashDir
+	}
+
+	return "./" + slashDir
+}
@@ -0,0 +1,105 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/louiss0/go-toolkit/cmd"
+	"github.com/louiss0/go-toolkit/internal/testhelpers"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var Workspace = Describe("workspace command", func() {
+	assert := assert.New(GinkgoT())
+
+	var runner *testhelpers.RunnerMock
+	var tempDir string
+
+	newRootCmd := func() *cobra.Command {
+		return cmd.NewRootCmdWithOptions(cmd.RootOptions{
+			Runner:       runner,
+			PromptRunner: testhelpers.NewPromptRunnerMock(),
+			ConfigPath:   filepath.Join(tempDir, "config.toml"),
+		})
+	}
+
+	BeforeEach(func() {
+		runner = &testhelpers.RunnerMock{}
+		tempDir = testhelpers.CanonicalPath(GinkgoT().TempDir())
+		workingDir, err := os.Getwd()
+		assert.NoError(err)
+
+		err = os.Chdir(tempDir)
+		assert.NoError(err)
+		DeferCleanup(func() {
+			_ = os.Chdir(workingDir)
+		})
+	})
+
+	It("creates a go.work with the given modules", func() {
+		runner.On("Run", mock.Anything, "go", []string{"work", "init", "./api", "./worker"}).Return(nil).Once()
+
+		_, err := testhelpers.ExecuteCmd(newRootCmd(), "workspace", "init", "api", "worker")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+	})
+
+	It("refuses to create a nested workspace", func() {
+		err := os.WriteFile(filepath.Join(tempDir, "go.work"), []byte("go 1.24\n"), 0o644)
+		assert.NoError(err)
+
+		_, err = testhelpers.ExecuteCmd(newRootCmd(), "workspace", "init")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "workspace already exists")
+	})
+
+	It("adds modules to the workspace", func() {
+		err := os.WriteFile(filepath.Join(tempDir, "go.work"), []byte("go 1.24\n"), 0o644)
+		assert.NoError(err)
+		runner.On("Run", mock.Anything, "go", []string{"work", "use", "-r", "./services"}).Return(nil).Once()
+
+		_, err = testhelpers.ExecuteCmd(newRootCmd(), "workspace", "use", "services", "-r")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+	})
+
+	It("drops modules from the workspace", func() {
+		err := os.WriteFile(filepath.Join(tempDir, "go.work"), []byte("go 1.24\n"), 0o644)
+		assert.NoError(err)
+		runner.On("Run", mock.Anything, "go", []string{"work", "edit", "-dropuse=./api", "-dropuse=./worker"}).Return(nil).Once()
+
+		_, err = testhelpers.ExecuteCmd(newRootCmd(), "workspace", "drop", "api", "worker")
+
+		assert.NoError(err)
+		runner.AssertExpectations(GinkgoT())
+	})
+
+	It("syncs from the workspace root", func() {
+		err := os.WriteFile(filepath.Join(tempDir, "go.work"), []byte("go 1.24\n"), 0o644)
+		assert.NoError(err)
+		nested := filepath.Join(tempDir, "api")
+		err = os.MkdirAll(nested, 0o755)
+		assert.NoError(err)
+		err = os.Chdir(nested)
+		assert.NoError(err)
+
+		output, err := testhelpers.ExecuteCmd(newRootCmd(), "workspace", "sync", "--dry-run")
+
+		assert.NoError(err)
+		assert.Equal("go -C "+tempDir+" work use -r .\ngo -C "+tempDir+" work sync\n", output)
+		runner.AssertNotCalled(GinkgoT(), "Run", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("requires a workspace for use", func() {
+		_, err := testhelpers.ExecuteCmd(newRootCmd(), "workspace", "use", "api")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "no go.work found")
+	})
+})
@@ -0,0 +1,11 @@
+package cache_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func TestCache(t *testing.T) {
+	RunSpecs(t, "Cache Suite")
+}
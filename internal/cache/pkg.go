@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const DefaultTTL = 24 * time.Hour
+
+var ErrMiss = errors.New("cache miss")
+
+type Store struct {
+	Dir string
+	TTL time.Duration
+}
+
+type Entry struct {
+	Content []byte
+	Fresh   bool
+}
+
+type Stats struct {
+	Entries int
+	Expired int
+	Bytes   int64
+}
+
+func DefaultDir() (string, error) {
+	if xdgCacheHome := strings.TrimSpace(os.Getenv("XDG_CACHE_HOME")); xdgCacheHome != "" {
+		return filepath.Join(xdgCacheHome, "go-toolkit"), nil
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, "go-toolkit"), nil
+}
+
+func NewStore(dir string, ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Store{Dir: dir, TTL: ttl}
+}
+
+func (s *Store) Get(key string) (Entry, error) {
+	path := s.path(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Entry{}, ErrMiss
+		}
+		return Entry{}, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	return Entry{Content: content, Fresh: !s.expired(info)}, nil
+}
+
+func (s *Store) Put(key string, content []byte) error {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(content); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), path)
+}
+
+func (s *Store) Stats() (Stats, error) {
+	stats := Stats{}
+	err := s.walk(func(path string, info fs.FileInfo) error {
+		stats.Entries++
+		stats.Bytes += info.Size()
+		if s.expired(info) {
+			stats.Expired++
+		}
+		return nil
+	})
+
+	return stats, err
+}
+
+func (s *Store) Prune() (int, error) {
+	removed := 0
+	err := s.walk(func(path string, info fs.FileInfo) error {
+		if !s.expired(info) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		removed++
+		return nil
+	})
+
+	return removed, err
+}
+
+func (s *Store) Clear() error {
+	return os.RemoveAll(s.Dir)
+}
+
+func (s *Store) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+
+	return filepath.Join(s.Dir, name[:2], name)
+}
+
+func (s *Store) expired(info fs.FileInfo) bool {
+	return time.Since(info.ModTime()) > s.TTL
+}
+
+func (s *Store) walk(visit func(path string, info fs.FileInfo) error) error {
+	err := filepath.WalkDir(s.Dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		return visit(path, info)
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
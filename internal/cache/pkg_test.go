@@ -0,0 +1,101 @@
+package cache_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/louiss0/go-toolkit/internal/cache"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Store", func() {
+	assert := assert.New(GinkgoT())
+
+	var store *cache.Store
+
+	BeforeEach(func() {
+		store = cache.NewStore(GinkgoT().TempDir(), time.Hour)
+	})
+
+	age := func(key string) {
+		err := store.Put(key, []byte("stale"))
+		assert.NoError(err)
+
+		err = filepath.WalkDir(store.Dir, func(path string, entry os.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			old := time.Now().Add(-2 * time.Hour)
+			return os.Chtimes(path, old, old)
+		})
+		assert.NoError(err)
+	}
+
+	It("reports misses for unknown keys", func() {
+		_, err := store.Get("https://proxy.golang.org/github.com/samber/lo/@v/list")
+
+		assert.ErrorIs(err, cache.ErrMiss)
+	})
+
+	It("returns fresh entries", func() {
+		err := store.Put("key", []byte("v1.0.0"))
+		assert.NoError(err)
+
+		entry, err := store.Get("key")
+
+		assert.NoError(err)
+		assert.Equal([]byte("v1.0.0"), entry.Content)
+		assert.True(entry.Fresh)
+	})
+
+	It("flags entries older than the TTL", func() {
+		age("key")
+
+		entry, err := store.Get("key")
+
+		assert.NoError(err)
+		assert.False(entry.Fresh)
+	})
+
+	It("prunes expired entries and keeps fresh ones", func() {
+		age("old")
+		err := store.Put("new", []byte("fresh"))
+		assert.NoError(err)
+
+		stats, err := store.Stats()
+		assert.NoError(err)
+		assert.Equal(cache.Stats{Entries: 2, Expired: 1, Bytes: 10}, stats)
+
+		removed, err := store.Prune()
+
+		assert.NoError(err)
+		assert.Equal(1, removed)
+		_, err = store.Get("old")
+		assert.ErrorIs(err, cache.ErrMiss)
+		_, err = store.Get("new")
+		assert.NoError(err)
+	})
+
+	It("clears every entry", func() {
+		err := store.Put("key", []byte("value"))
+		assert.NoError(err)
+
+		err = store.Clear()
+
+		assert.NoError(err)
+		stats, err := store.Stats()
+		assert.NoError(err)
+		assert.Zero(stats.Entries)
+	})
+
+	It("defaults to the XDG cache home", func() {
+		GinkgoT().Setenv("XDG_CACHE_HOME", "/tmp/xdg-cache")
+
+		dir, err := cache.DefaultDir()
+
+		assert.NoError(err)
+		assert.Equal(filepath.Join("/tmp/xdg-cache", "go-toolkit"), dir)
+	})
+})
@@ -0,0 +1,18 @@
+package cmdutil
+
+import (
+	"errors"
+
+	"github.com/louiss0/go-toolkit/custom_errors"
+	"github.com/louiss0/go-toolkit/internal/prompt"
+)
+
+// MissingInput replaces prompt.ErrNoInput and prompt.ErrPromptsDisabled with a
+// hint naming the arguments or flags that stand in for the prompt.
+func MissingInput(err error, hint string) error {
+	if errors.Is(err, prompt.ErrNoInput) || errors.Is(err, prompt.ErrPromptsDisabled) {
+		return custom_errors.CreateInvalidInputErrorWithMessage(hint)
+	}
+
+	return err
+}
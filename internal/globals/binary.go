@@ -0,0 +1,93 @@
+package globals
+
+import (
+	"crypto/sha256"
+	"debug/buildinfo"
+	"encoding/hex"
+	"go/build"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/louiss0/go-toolkit/internal/modproxy"
+	"github.com/samber/lo"
+)
+
+var majorVersionElement = regexp.MustCompile(`^v[0-9]+$`)
+
+// Binary describes an installed executable and the build info embedded in it.
+type Binary struct {
+	Path      string
+	Package   string
+	Module    string
+	Version   string
+	GoVersion string
+	Hash      string
+}
+
+// BinDir mirrors where go install writes binaries: GOBIN, otherwise the bin
+// directory of the first GOPATH entry. Both are read from the environment
+// first and then from the go env file that go env -w writes.
+func BinDir() string {
+	env := modproxy.LoadEnv()
+	if env.GoBin != "" {
+		return env.GoBin
+	}
+
+	gopaths := filepath.SplitList(lo.CoalesceOrEmpty(env.GoPath, build.Default.GOPATH))
+	if len(gopaths) == 0 {
+		return ""
+	}
+
+	return filepath.Join(gopaths[0], "bin")
+}
+
+// BinaryName returns the executable go install produces for packagePath,
+// skipping a trailing major version element such as /v2.
+func BinaryName(packagePath string) string {
+	name := path.Base(packagePath)
+	if majorVersionElement.MatchString(name) && strings.Contains(packagePath, "/") {
+		name = path.Base(path.Dir(packagePath))
+	}
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+
+	return name
+}
+
+func InspectBinary(binaryPath string) (Binary, error) {
+	hash, err := hashFile(binaryPath)
+	if err != nil {
+		return Binary{}, err
+	}
+
+	binary := Binary{Path: binaryPath, Hash: hash}
+	if info, err := buildinfo.ReadFile(binaryPath); err == nil {
+		binary.Package = info.Path
+		binary.Module = info.Main.Path
+		binary.Version = info.Main.Version
+		binary.GoVersion = info.GoVersion
+	}
+
+	return binary, nil
+}
+
+func hashFile(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,88 @@
+package globals
+
+import (
+	"debug/buildinfo"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/samber/lo"
+)
+
+// ErrBinaryConflict reports a file in the bin directory that was not built
+// from the package it is named after.
+var ErrBinaryConflict = errors.New("binary belongs to another package")
+
+// Drift compares the recorded global packages with what is in the bin
+// directory.
+type Drift struct {
+	BinDir     string
+	Missing    []string
+	Modified   []string
+	Unrecorded []Binary
+}
+
+func (d Drift) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Modified) == 0 && len(d.Unrecorded) == 0
+}
+
+// Diagnose lists recorded packages without a binary, binaries whose hash no
+// longer matches the lock, and binaries nobody recorded.
+func Diagnose(binDir string, packagePaths []string, lock Lock) (Drift, error) {
+	drift := Drift{BinDir: binDir}
+	recorded := map[string]struct{}{}
+
+	for _, packagePath := range packagePaths {
+		binaryName := BinaryName(packagePath)
+		recorded[binaryName] = struct{}{}
+
+		binary, err := InspectBinary(filepath.Join(binDir, binaryName))
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return Drift{}, err
+			}
+			drift.Missing = append(drift.Missing, packagePath)
+			continue
+		}
+		if entry, found := lock.Find(packagePath); found && entry.Hash != binary.Hash {
+			drift.Modified = append(drift.Modified, packagePath)
+		}
+	}
+
+	entries, err := os.ReadDir(binDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Drift{}, err
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || lo.HasKey(recorded, entry.Name()) {
+			continue
+		}
+		binary, err := InspectBinary(filepath.Join(binDir, entry.Name()))
+		if err != nil {
+			return Drift{}, err
+		}
+		drift.Unrecorded = append(drift.Unrecorded, binary)
+	}
+
+	return drift, nil
+}
+
+// RemoveBinary deletes the executable go install produced for packagePath.
+// A binary that is already gone is not an error; a file whose build info
+// names another package, or none at all, is left in place.
+func RemoveBinary(binDir string, packagePath string) error {
+	binaryPath := filepath.Join(binDir, BinaryName(packagePath))
+	info, err := buildinfo.ReadFile(binaryPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s is not a Go binary built from %s", ErrBinaryConflict, binaryPath, packagePath)
+	}
+	if info.Path != packagePath {
+		return fmt.Errorf("%w: %s was built from %s, not %s", ErrBinaryConflict, binaryPath, info.Path, packagePath)
+	}
+
+	return os.Remove(binaryPath)
+}
@@ -0,0 +1,11 @@
+package globals_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func TestGlobals(t *testing.T) {
+	RunSpecs(t, "Globals Suite")
+}
@@ -0,0 +1,99 @@
+package globals
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/samber/lo"
+)
+
+const LockFileName = "gtk-globals.lock"
+
+// Lock records what install actually put in the bin directory for each
+// global package.
+type Lock struct {
+	Packages []Entry `toml:"packages"`
+}
+
+type Entry struct {
+	Path      string `toml:"path"`
+	Module    string `toml:"module,omitempty"`
+	Version   string `toml:"version"`
+	GoVersion string `toml:"go_version"`
+	Binary    string `toml:"binary"`
+	Hash      string `toml:"hash"`
+}
+
+// LockPath places the lock file next to the config file.
+func LockPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), LockFileName)
+}
+
+func LoadLock(path string) (Lock, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Lock{}, nil
+		}
+		return Lock{}, err
+	}
+
+	var lock Lock
+	if err := toml.Unmarshal(content, &lock); err != nil {
+		return Lock{}, err
+	}
+
+	return lock, nil
+}
+
+func SaveLock(path string, lock Lock) error {
+	packages := append([]Entry{}, lock.Packages...)
+	slices.SortFunc(packages, func(a Entry, b Entry) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	content, err := toml.Marshal(Lock{Packages: packages})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o644)
+}
+
+func (l Lock) Find(path string) (Entry, bool) {
+	return lo.Find(l.Packages, func(entry Entry) bool {
+		return entry.Path == path
+	})
+}
+
+// Upsert replaces entries with the same path and appends the rest.
+func (l Lock) Upsert(entries ...Entry) Lock {
+	paths := lo.Map(entries, func(entry Entry, _ int) string {
+		return entry.Path
+	})
+	packages := lo.Reject(l.Packages, func(entry Entry, _ int) bool {
+		return lo.Contains(paths, entry.Path)
+	})
+
+	return Lock{Packages: append(packages, entries...)}
+}
+
+func (l Lock) Remove(paths []string) Lock {
+	return Lock{Packages: lo.Reject(l.Packages, func(entry Entry, _ int) bool {
+		return lo.Contains(paths, entry.Path)
+	})}
+}
+
+// Retain drops entries whose path is not listed.
+func (l Lock) Retain(paths []string) Lock {
+	return Lock{Packages: lo.Filter(l.Packages, func(entry Entry, _ int) bool {
+		return lo.Contains(paths, entry.Path)
+	})}
+}
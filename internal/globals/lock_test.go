@@ -0,0 +1,167 @@
+package globals_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/louiss0/go-toolkit/internal/globals"
+	"github.com/louiss0/go-toolkit/internal/testhelpers"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Lock", func() {
+	assert := assert.New(GinkgoT())
+
+	It("places the lock next to the config", func() {
+		assert.Equal(filepath.Join("home", "go-toolkit", globals.LockFileName), globals.LockPath(filepath.Join("home", "go-toolkit", "gtk-config.toml")))
+	})
+
+	It("returns an empty lock when the file is missing", func() {
+		lock, err := globals.LoadLock(filepath.Join(GinkgoT().TempDir(), globals.LockFileName))
+
+		assert.NoError(err)
+		assert.Empty(lock.Packages)
+	})
+
+	It("saves entries sorted by path and loads them back", func() {
+		lockPath := filepath.Join(GinkgoT().TempDir(), globals.LockFileName)
+		lock := globals.Lock{Packages: []globals.Entry{
+			{Path: "mvdan.cc/gofumpt", Version: "v0.7.0", GoVersion: "go1.24.0", Binary: "gofumpt", Hash: "sha256:b"},
+			{Path: "github.com/samber/lo", Version: "v1.49.1", GoVersion: "go1.24.0", Binary: "lo", Hash: "sha256:a"},
+		}}
+
+		err := globals.SaveLock(lockPath, lock)
+		assert.NoError(err)
+		loaded, err := globals.LoadLock(lockPath)
+
+		assert.NoError(err)
+		assert.Equal([]string{"github.com/samber/lo", "mvdan.cc/gofumpt"}, []string{loaded.Packages[0].Path, loaded.Packages[1].Path})
+		assert.Equal("sha256:b", loaded.Packages[1].Hash)
+	})
+
+	It("replaces and prunes entries by path", func() {
+		lock := globals.Lock{Packages: []globals.Entry{
+			{Path: "github.com/samber/lo", Version: "v1.0.0"},
+			{Path: "mvdan.cc/gofumpt", Version: "v0.7.0"},
+		}}
+
+		lock = lock.Upsert(globals.Entry{Path: "github.com/samber/lo", Version: "v1.49.1"}).Retain([]string{"github.com/samber/lo"})
+
+		assert.Equal([]globals.Entry{{Path: "github.com/samber/lo", Version: "v1.49.1"}}, lock.Packages)
+	})
+})
+
+var _ = Describe("Binary", func() {
+	assert := assert.New(GinkgoT())
+
+	It("names binaries after the last non-major path element", func() {
+		suffix := ""
+		if runtime.GOOS == "windows" {
+			suffix = ".exe"
+		}
+
+		assert.Equal("goimports"+suffix, globals.BinaryName("golang.org/x/tools/cmd/goimports"))
+		assert.Equal("ginkgo"+suffix, globals.BinaryName("github.com/onsi/ginkgo/v2"))
+	})
+
+	It("prefers GOBIN for the bin directory", func() {
+		binDir := GinkgoT().TempDir()
+		GinkgoT().Setenv("GOBIN", binDir)
+
+		assert.Equal(binDir, globals.BinDir())
+	})
+
+	It("reads GOBIN from the go env file", func() {
+		binDir := GinkgoT().TempDir()
+		envFile := filepath.Join(GinkgoT().TempDir(), "env")
+		assert.NoError(os.WriteFile(envFile, []byte("GOBIN="+binDir+"\n"), 0o644))
+		GinkgoT().Setenv("GOENV", envFile)
+		GinkgoT().Setenv("GOBIN", "")
+		assert.NoError(os.Unsetenv("GOBIN"))
+
+		assert.Equal(binDir, globals.BinDir())
+	})
+
+	It("falls back to GOPATH from the go env file", func() {
+		goPath := GinkgoT().TempDir()
+		envFile := filepath.Join(GinkgoT().TempDir(), "env")
+		assert.NoError(os.WriteFile(envFile, []byte("GOPATH="+goPath+"\n"), 0o644))
+		GinkgoT().Setenv("GOENV", envFile)
+		GinkgoT().Setenv("GOBIN", "")
+		assert.NoError(os.Unsetenv("GOBIN"))
+		GinkgoT().Setenv("GOPATH", "")
+		assert.NoError(os.Unsetenv("GOPATH"))
+
+		assert.Equal(filepath.Join(goPath, "bin"), globals.BinDir())
+	})
+
+	It("reads the hash and go version of a binary", func() {
+		executable, err := os.Executable()
+		assert.NoError(err)
+
+		binary, err := globals.InspectBinary(executable)
+
+		assert.NoError(err)
+		assert.True(strings.HasPrefix(binary.Hash, "sha256:"))
+		assert.Equal(runtime.Version(), binary.GoVersion)
+	})
+})
+
+var _ = Describe("Diagnose", func() {
+	assert := assert.New(GinkgoT())
+
+	It("finds missing, modified and unrecorded binaries", func() {
+		binDir := GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(binDir, globals.BinaryName("github.com/samber/lo")), []byte("lo"), 0o755))
+		assert.NoError(os.WriteFile(filepath.Join(binDir, "stray"), []byte("stray"), 0o755))
+		lock := globals.Lock{Packages: []globals.Entry{{Path: "github.com/samber/lo", Hash: "sha256:old"}}}
+
+		drift, err := globals.Diagnose(binDir, []string{"github.com/samber/lo", "mvdan.cc/gofumpt"}, lock)
+
+		assert.NoError(err)
+		assert.Equal([]string{"mvdan.cc/gofumpt"}, drift.Missing)
+		assert.Equal([]string{"github.com/samber/lo"}, drift.Modified)
+		assert.Len(drift.Unrecorded, 1)
+		assert.Equal(filepath.Join(binDir, "stray"), drift.Unrecorded[0].Path)
+		assert.Empty(drift.Unrecorded[0].Package)
+	})
+
+	It("treats an already removed binary as removed", func() {
+		assert.NoError(globals.RemoveBinary(GinkgoT().TempDir(), "github.com/samber/lo"))
+	})
+
+	It("removes a binary built from the package", func() {
+		binDir := GinkgoT().TempDir()
+		binaryPath := filepath.Join(binDir, globals.BinaryName("example.com/tools/lint"))
+		assert.NoError(testhelpers.BuildGoBinary(binaryPath, "example.com/tools/lint"))
+
+		assert.NoError(globals.RemoveBinary(binDir, "example.com/tools/lint"))
+		assert.NoFileExists(binaryPath)
+	})
+
+	It("keeps a binary built from another package", func() {
+		binDir := GinkgoT().TempDir()
+		binaryPath := filepath.Join(binDir, globals.BinaryName("example.com/tools/lint"))
+		assert.NoError(testhelpers.BuildGoBinary(binaryPath, "example.com/other/lint"))
+
+		err := globals.RemoveBinary(binDir, "example.com/tools/lint")
+
+		assert.ErrorIs(err, globals.ErrBinaryConflict)
+		assert.Contains(err.Error(), "was built from example.com/other/lint")
+		assert.FileExists(binaryPath)
+	})
+
+	It("keeps a file that is not a Go binary", func() {
+		binDir := GinkgoT().TempDir()
+		binaryPath := filepath.Join(binDir, globals.BinaryName("github.com/samber/lo"))
+		assert.NoError(os.WriteFile(binaryPath, []byte("lo"), 0o755))
+
+		err := globals.RemoveBinary(binDir, "github.com/samber/lo")
+
+		assert.ErrorIs(err, globals.ErrBinaryConflict)
+		assert.FileExists(binaryPath)
+	})
+})
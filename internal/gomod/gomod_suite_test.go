@@ -0,0 +1,11 @@
+package gomod_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func TestGomod(t *testing.T) {
+	RunSpecs(t, "Gomod Suite")
+}
@@ -0,0 +1,40 @@
+package gomod
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const WorkFileName = "go.work"
+
+var ErrWorkFileNotFound = errors.New("no go.work found")
+
+func FindWorkFile(dir string) (string, error) {
+	return findUp(dir, WorkFileName, ErrWorkFileNotFound)
+}
+
+func findUp(dir string, fileName string, notFound error) (string, error) {
+	current, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		candidate := filepath.Join(current, fileName)
+		info, err := os.Stat(candidate)
+		if err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", notFound
+		}
+		current = parent
+	}
+}
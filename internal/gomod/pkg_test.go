@@ -0,0 +1,102 @@
+package gomod_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/louiss0/go-toolkit/internal/gomod"
+	"github.com/louiss0/go-toolkit/internal/testhelpers"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("FindWorkFile", func() {
+	assert := assert.New(GinkgoT())
+
+	It("finds the nearest go.work in a parent folder", func() {
+		root := testhelpers.CanonicalPath(GinkgoT().TempDir())
+		nested := filepath.Join(root, "services", "billing")
+		err := os.MkdirAll(nested, 0o755)
+		assert.NoError(err)
+		err = os.WriteFile(filepath.Join(root, "go.work"), []byte("go 1.24\n"), 0o644)
+		assert.NoError(err)
+
+		workFile, err := gomod.FindWorkFile(nested)
+
+		assert.NoError(err)
+		assert.Equal(filepath.Join(root, "go.work"), workFile)
+	})
+
+	It("reports when no go.work exists", func() {
+		_, err := gomod.FindWorkFile(GinkgoT().TempDir())
+
+		assert.ErrorIs(err, gomod.ErrWorkFileNotFound)
+	})
+})
+
+var _ = Describe("Diff", func() {
+	assert := assert.New(GinkgoT())
+
+	writeModFile := func(dir string, requires string) string {
+		modFilePath := filepath.Join(dir, "go.mod")
+		err := os.WriteFile(modFilePath, []byte("module github.com/lou/toolkit\n\ngo 1.24\n\n"+requires), 0o644)
+		assert.NoError(err)
+
+		return modFilePath
+	}
+
+	It("classifies added, upgraded, downgraded and removed requirements", func() {
+		dir := GinkgoT().TempDir()
+		before, err := gomod.ReadRequirements(writeModFile(dir, `require (
+	github.com/samber/lo v1.49.1
+	github.com/spf13/cobra v1.10.2
+	golang.org/x/mod v0.30.0 // indirect
+	gopkg.in/ini.v1 v1.67.0
+)
+`))
+		assert.NoError(err)
+
+		after, err := gomod.ReadRequirements(writeModFile(dir, `require (
+	github.com/samber/lo v1.52.0
+	github.com/spf13/cobra v1.9.0
+	github.com/stretchr/testify v1.11.1
+	golang.org/x/mod v0.30.0 // indirect
+)
+`))
+		assert.NoError(err)
+
+		changes := gomod.Diff(before, after)
+
+		assert.Equal([]gomod.Change{{Path: "github.com/stretchr/testify", To: "v1.11.1"}}, changes.Added)
+		assert.Equal([]gomod.Change{{Path: "github.com/samber/lo", From: "v1.49.1", To: "v1.52.0"}}, changes.Upgraded)
+		assert.Equal([]gomod.Change{{Path: "github.com/spf13/cobra", From: "v1.10.2", To: "v1.9.0"}}, changes.Downgraded)
+		assert.Equal([]gomod.Change{{Path: "gopkg.in/ini.v1", From: "v1.67.0"}}, changes.Removed)
+		assert.Equal([]string{
+			"added github.com/stretchr/testify v1.11.1",
+			"upgraded github.com/samber/lo v1.49.1 => v1.52.0",
+			"downgraded github.com/spf13/cobra v1.10.2 => v1.9.0",
+			"removed gopkg.in/ini.v1 v1.67.0",
+		}, changes.Lines())
+	})
+
+	It("reports indirect requirements that become direct at the same version", func() {
+		dir := GinkgoT().TempDir()
+		before, err := gomod.ReadRequirements(writeModFile(dir, "require golang.org/x/mod v0.30.0 // indirect\n"))
+		assert.NoError(err)
+		after, err := gomod.ReadRequirements(writeModFile(dir, "require golang.org/x/mod v0.30.0\n"))
+		assert.NoError(err)
+
+		changes := gomod.Diff(before, after)
+
+		assert.False(changes.Empty())
+		assert.Equal([]gomod.Change{{Path: "golang.org/x/mod", From: "v0.30.0", To: "v0.30.0"}}, changes.Promoted)
+		assert.Equal([]string{"promoted golang.org/x/mod v0.30.0 from indirect to direct"}, changes.Lines())
+	})
+
+	It("reports no changes for identical requirements", func() {
+		requirements, err := gomod.ReadRequirements(writeModFile(GinkgoT().TempDir(), "require github.com/samber/lo v1.49.1\n"))
+		assert.NoError(err)
+
+		assert.True(gomod.Diff(requirements, requirements).Empty())
+	})
+})
@@ -0,0 +1,137 @@
+package gomod
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+const ModFileName = "go.mod"
+
+var ErrModFileNotFound = errors.New("no go.mod found")
+
+type Requirement struct {
+	Path     string
+	Version  string
+	Indirect bool
+}
+
+type Change struct {
+	Path     string
+	From     string
+	To       string
+	Indirect bool
+}
+
+type Changes struct {
+	Added      []Change
+	Upgraded   []Change
+	Downgraded []Change
+	Promoted   []Change
+	Removed    []Change
+}
+
+func FindModFile(dir string) (string, error) {
+	return findUp(dir, ModFileName, ErrModFileNotFound)
+}
+
+func ReadRequirements(modFilePath string) (map[string]Requirement, error) {
+	content, err := os.ReadFile(modFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := modfile.ParseLax(modFilePath, content, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	requirements := make(map[string]Requirement, len(file.Require))
+	for _, require := range file.Require {
+		requirements[require.Mod.Path] = Requirement{
+			Path:     require.Mod.Path,
+			Version:  require.Mod.Version,
+			Indirect: require.Indirect,
+		}
+	}
+
+	return requirements, nil
+}
+
+func Diff(before map[string]Requirement, after map[string]Requirement) Changes {
+	changes := Changes{
+		Added:      []Change{},
+		Upgraded:   []Change{},
+		Downgraded: []Change{},
+		Promoted:   []Change{},
+		Removed:    []Change{},
+	}
+
+	for _, path := range sortedPaths(after) {
+		current := after[path]
+		previous, existed := before[path]
+		change := Change{Path: path, From: previous.Version, To: current.Version, Indirect: current.Indirect}
+
+		switch comparison := semver.Compare(current.Version, previous.Version); {
+		case !existed:
+			changes.Added = append(changes.Added, change)
+		case comparison > 0:
+			changes.Upgraded = append(changes.Upgraded, change)
+		case comparison < 0:
+			changes.Downgraded = append(changes.Downgraded, change)
+		case previous.Indirect && !current.Indirect:
+			// go get on an indirect dependency makes it direct without
+			// touching its version.
+			changes.Promoted = append(changes.Promoted, change)
+		}
+	}
+
+	for _, path := range sortedPaths(before) {
+		if _, exists := after[path]; exists {
+			continue
+		}
+		previous := before[path]
+		changes.Removed = append(changes.Removed, Change{Path: path, From: previous.Version, Indirect: previous.Indirect})
+	}
+
+	return changes
+}
+
+func (c Changes) Empty() bool {
+	return len(c.Added)+len(c.Upgraded)+len(c.Downgraded)+len(c.Promoted)+len(c.Removed) == 0
+}
+
+func (c Changes) Lines() []string {
+	lines := []string{}
+	for _, change := range c.Added {
+		lines = append(lines, strings.Join([]string{"added", change.Path, change.To}, " "))
+	}
+	for _, change := range c.Upgraded {
+		lines = append(lines, strings.Join([]string{"upgraded", change.Path, change.From, "=>", change.To}, " "))
+	}
+	for _, change := range c.Downgraded {
+		lines = append(lines, strings.Join([]string{"downgraded", change.Path, change.From, "=>", change.To}, " "))
+	}
+	for _, change := range c.Promoted {
+		lines = append(lines, strings.Join([]string{"promoted", change.Path, change.To, "from indirect to direct"}, " "))
+	}
+	for _, change := range c.Removed {
+		lines = append(lines, strings.Join([]string{"removed", change.Path, change.From}, " "))
+	}
+
+	return lines
+}
+
+func sortedPaths(requirements map[string]Requirement) []string {
+	paths := make([]string, 0, len(requirements))
+	for path := range requirements {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	return paths
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/samber/lo"
+	modsemver "golang.org/x/mod/semver"
+)
+
+// GlobalPackage is a globally installed package. Version is empty for the
+// latest release, an exact version such as v1.2.3, or a semver constraint.
+type GlobalPackage struct {
+	Path    string `mapstructure:"path" toml:"path" gozod:"required,min=1"`
+	Version string `mapstructure:"version" toml:"version,omitempty"`
+}
+
+// ParseGlobalPackage reads a path@version entry; @latest counts as unpinned.
+func ParseGlobalPackage(entry string) GlobalPackage {
+	path, version, _ := strings.Cut(strings.TrimSpace(entry), "@")
+	version = strings.TrimSpace(version)
+	if version == "latest" {
+		version = ""
+	}
+
+	return GlobalPackage{Path: strings.TrimSpace(path), Version: version}
+}
+
+func (p GlobalPackage) String() string {
+	if p.Version == "" {
+		return p.Path
+	}
+
+	return p.Path + "@" + p.Version
+}
+
+// IsExact reports whether Version names a single version that go install
+// accepts as-is.
+func (p GlobalPackage) IsExact() bool {
+	if !modsemver.IsValid(p.Version) {
+		return false
+	}
+	version, _, _ := strings.Cut(p.Version, "+")
+
+	return modsemver.Canonical(p.Version) == version
+}
+
+func GlobalPackagePaths(packages []GlobalPackage) []string {
+	return lo.Map(packages, func(globalPackage GlobalPackage, _ int) string {
+		return globalPackage.Path
+	})
+}
+
+// UpsertGlobalPackages appends new packages and replaces the version of
+// packages that are already saved.
+func UpsertGlobalPackages(packages []GlobalPackage, updates ...GlobalPackage) []GlobalPackage {
+	result := append([]GlobalPackage{}, packages...)
+	for _, update := range updates {
+		_, index, found := lo.FindIndexOf(result, func(globalPackage GlobalPackage) bool {
+			return globalPackage.Path == update.Path
+		})
+		if found {
+			result[index] = update
+			continue
+		}
+		result = append(result, update)
+	}
+
+	return result
+}
+
+func RemoveGlobalPackages(packages []GlobalPackage, paths []string) []GlobalPackage {
+	return lo.Reject(packages, func(globalPackage GlobalPackage, _ int) bool {
+		return lo.Contains(paths, globalPackage.Path)
+	})
+}
+
+func validateGlobalPackages(packages []GlobalPackage) error {
+	for _, globalPackage := range packages {
+		if strings.ContainsAny(globalPackage.Path, " \t@") {
+			return fmt.Errorf("invalid config values: global package %q must be a module path", globalPackage.Path)
+		}
+		if globalPackage.Version == "" || globalPackage.IsExact() {
+			continue
+		}
+		if _, err := semver.NewConstraint(globalPackage.Version); err != nil {
+			return fmt.Errorf("invalid config values: global package %s has an invalid version %q", globalPackage.Path, globalPackage.Version)
+		}
+	}
+
+	return nil
+}
+
+// globalPackageDecodeHook keeps configs that list global packages as plain
+// path or path@version strings loading.
+func globalPackageDecodeHook(from reflect.Type, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf(GlobalPackage{}) {
+		return data, nil
+	}
+
+	return ParseGlobalPackage(data.(string)), nil
+}
@@ -6,10 +6,12 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/kaptinlin/gozod"
 	"github.com/louiss0/go-toolkit/custom_errors"
 	"github.com/louiss0/go-toolkit/validation"
+	"github.com/mitchellh/mapstructure"
 	"github.com/samber/lo"
 	"github.com/spf13/viper"
 )
@@ -31,7 +33,9 @@ type Values struct {
 	Scaffold        ScaffoldConfig      `mapstructure:"scaffold" toml:"scaffold"`
 	Providers       []ProviderConfig    `mapstructure:"providers" toml:"providers"`
 	PackagePresets  map[string][]string `mapstructure:"package_presets" toml:"package_presets"`
-	GlobalPackages  []string            `mapstructure:"global_packages" toml:"global_packages"`
+	GlobalPackages  []GlobalPackage     `mapstructure:"global_packages" toml:"global_packages"`
+	Templates       map[string]string   `mapstructure:"templates" toml:"templates"`
+	Search          SearchConfig        `mapstructure:"search" toml:"search"`
 }
 
 type ProviderConfig struct {
@@ -39,6 +43,11 @@ type ProviderConfig struct {
 	Path string `mapstructure:"path" toml:"path" gozod:"required,min=1"`
 }
 
+type SearchConfig struct {
+	Proxy    string `mapstructure:"proxy" toml:"proxy,omitempty" gozod:"regex=^\\S*$"`
+	CacheTTL string `mapstructure:"cache_ttl" toml:"cache_ttl,omitempty"`
+}
+
 type ScaffoldConfig struct {
 	WriteTests bool  `mapstructure:"write_tests" toml:"write_tests"`
 	InitGit    *bool `mapstructure:"init_git" toml:"init_git"`
@@ -76,7 +85,11 @@ func Load(path string) (Values, error) {
 	}
 
 	var values Values
-	if err := configFile.Unmarshal(&values); err != nil {
+	if err := configFile.Unmarshal(&values, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		globalPackageDecodeHook,
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	))); err != nil {
 		return Values{}, err
 	}
 
@@ -116,6 +129,12 @@ func Save(path string, values Values) error {
 	if len(values.GlobalPackages) > 0 {
 		configFile.Set("global_packages", values.GlobalPackages)
 	}
+	if len(values.Templates) > 0 {
+		configFile.Set("templates", values.Templates)
+	}
+	if values.Search != (SearchConfig{}) {
+		configFile.Set("search", values.Search)
+	}
 
 	return configFile.WriteConfigAs(path)
 }
@@ -125,14 +144,28 @@ func validateValues(values Values) error {
 		return custom_errors.FromZod(err, custom_errors.ZodTheme{
 			Subject: "go scaffolding config",
 			FieldMessages: map[string]string{
-				"User": "config user must not contain spaces",
-				"Site": "config site must be in the form sitename.domain",
+				"User":  "config user must not contain spaces",
+				"Site":  "config site must be in the form sitename.domain",
+				"Proxy": "config search proxy must not contain spaces",
 			},
 		})
 	}
+	if values.Search.CacheTTL != "" {
+		if ttl, err := time.ParseDuration(values.Search.CacheTTL); err != nil || ttl <= 0 {
+			return custom_errors.CreateInvalidInputErrorWithMessage(
+				"config search cache_ttl must be a positive duration such as 12h",
+			)
+		}
+	}
 	if err := validatePackagePresets(values.PackagePresets); err != nil {
 		return err
 	}
+	if err := validateTemplates(values.Templates); err != nil {
+		return err
+	}
+	if err := validateGlobalPackages(values.GlobalPackages); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -169,6 +202,15 @@ func IsValidSite(site string) bool {
 	return validation.IsValidSite(site)
 }
 
+func ResolveCacheTTL(values Values) time.Duration {
+	ttl, err := time.ParseDuration(values.Search.CacheTTL)
+	if err != nil {
+		return 0
+	}
+
+	return ttl
+}
+
 func ResolveInitGit(values Values) bool {
 	if values.Scaffold.InitGit == nil {
 		return true
@@ -96,6 +96,9 @@ var _ = Describe("ConfigLoadSave", func() {
 			PackagePresets: map[string][]string{
 				"cli": {"github.com/spf13/cobra"},
 			},
+			Templates: map[string]string{
+				"grpc": "/tmp/templates/grpc",
+			},
 		}
 
 		err := config.Save(path, values)
@@ -317,3 +320,97 @@ var _ = Describe("ResolvePackagePresetPackages", func() {
 		assert.Contains(err.Error(), "unknown package preset")
 	})
 })
+
+var _ = Describe("ResolveTemplateDirs", func() {
+	assert := assert.New(GinkgoT())
+
+	It("anchors relative template paths to the config folder", func() {
+		configDir := GinkgoT().TempDir()
+		absoluteDir := filepath.Join(GinkgoT().TempDir(), "worker")
+		values := config.Values{
+			Templates: map[string]string{
+				"grpc":   "templates/grpc",
+				"worker": absoluteDir,
+			},
+		}
+
+		dirs := config.ResolveTemplateDirs(filepath.Join(configDir, "gtk-config.toml"), values)
+
+		assert.Equal(filepath.Join(configDir, "templates", "grpc"), dirs["grpc"])
+		assert.Equal(absoluteDir, dirs["worker"])
+	})
+
+	It("rejects templates without a path", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+		err := config.Save(path, config.Values{Templates: map[string]string{"grpc": " "}})
+
+		assert.Error(err)
+	})
+})
+
+var _ = Describe("GlobalPackages", func() {
+	assert := assert.New(GinkgoT())
+
+	It("loads legacy string entries with optional versions", func() {
+		configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+		err := os.WriteFile(configPath, []byte("global_packages = [\"github.com/samber/lo\", \"mvdan.cc/gofumpt@v0.7.0\"]\n"), 0o644)
+		assert.NoError(err)
+
+		values, err := config.Load(configPath)
+
+		assert.NoError(err)
+		assert.Equal([]config.GlobalPackage{
+			{Path: "github.com/samber/lo"},
+			{Path: "mvdan.cc/gofumpt", Version: "v0.7.0"},
+		}, values.GlobalPackages)
+	})
+
+	It("saves and loads structured entries", func() {
+		configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+		packages := []config.GlobalPackage{
+			{Path: "github.com/samber/lo"},
+			{Path: "mvdan.cc/gofumpt", Version: "v0.7.0"},
+			{Path: "golang.org/x/tools/cmd/goimports", Version: ">=0.20.0 <0.30.0"},
+		}
+
+		err := config.Save(configPath, config.Values{GlobalPackages: packages})
+		assert.NoError(err)
+		values, err := config.Load(configPath)
+
+		assert.NoError(err)
+		assert.Equal(packages, values.GlobalPackages)
+	})
+
+	It("rejects invalid version constraints", func() {
+		configPath := filepath.Join(GinkgoT().TempDir(), "config.toml")
+
+		err := config.Save(configPath, config.Values{GlobalPackages: []config.GlobalPackage{
+			{Path: "github.com/samber/lo", Version: ">>1"},
+		}})
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "invalid version")
+	})
+
+	It("replaces the version of packages that are already saved", func() {
+		packages := config.UpsertGlobalPackages(
+			[]config.GlobalPackage{{Path: "github.com/samber/lo"}, {Path: "mvdan.cc/gofumpt"}},
+			config.ParseGlobalPackage("mvdan.cc/gofumpt@v0.7.0"),
+			config.ParseGlobalPackage("github.com/acme/tool@latest"),
+		)
+
+		assert.Equal([]config.GlobalPackage{
+			{Path: "github.com/samber/lo"},
+			{Path: "mvdan.cc/gofumpt", Version: "v0.7.0"},
+			{Path: "github.com/acme/tool"},
+		}, packages)
+	})
+
+	It("tells exact versions apart from constraints", func() {
+		assert.True(config.GlobalPackage{Version: "v1.2.3"}.IsExact())
+		assert.True(config.GlobalPackage{Version: "v2.0.0+incompatible"}.IsExact())
+		assert.False(config.GlobalPackage{Version: "v1.2"}.IsExact())
+		assert.False(config.GlobalPackage{Version: "^1.2.0"}.IsExact())
+	})
+})
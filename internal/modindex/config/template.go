@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+func KnownTemplateNames(values Values) []string {
+	names := make([]string, 0, len(values.Templates))
+	for name := range values.Templates {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+func ResolveTemplateDirs(configPath string, values Values) map[string]string {
+	dirs := make(map[string]string, len(values.Templates))
+	for name, dir := range values.Templates {
+		dirs[name] = resolveTemplateDir(configPath, dir)
+	}
+
+	return dirs
+}
+
+func resolveTemplateDir(configPath string, dir string) string {
+	trimmed := strings.TrimSpace(dir)
+	if trimmed == "~" || strings.HasPrefix(trimmed, "~/") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homeDir, strings.TrimPrefix(trimmed, "~"))
+		}
+	}
+
+	if filepath.IsAbs(trimmed) || configPath == "" {
+		return filepath.Clean(trimmed)
+	}
+
+	return filepath.Join(filepath.Dir(configPath), trimmed)
+}
+
+func validateTemplates(templates map[string]string) error {
+	for name, dir := range templates {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid config values: template name is required")
+		}
+		if strings.ContainsAny(name, " \t\r\n") {
+			return fmt.Errorf("invalid config values: template name %s must not contain spaces", name)
+		}
+		if strings.TrimSpace(dir) == "" {
+			return fmt.Errorf("invalid config values: template %s must include a path", name)
+		}
+	}
+
+	return nil
+}
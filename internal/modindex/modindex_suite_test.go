@@ -0,0 +1,11 @@
+package modindex_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func TestModindex(t *testing.T) {
+	RunSpecs(t, "Modindex Suite")
+}
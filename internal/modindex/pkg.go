@@ -0,0 +1,216 @@
+package modindex
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/louiss0/go-toolkit/internal/cache"
+	modsemver "golang.org/x/mod/semver"
+)
+
+const (
+	DefaultURL      = "https://index.golang.org/index"
+	PageSize        = 2000
+	DefaultMaxPages = 10
+	// SeedWindow is how far back an empty cache starts reading the feed. The
+	// feed begins in 2019, so reading from the start would never reach
+	// current releases within the page cap.
+	SeedWindow = 7 * 24 * time.Hour
+	// RefreshBudget bounds one refresh however many pages it still has to read.
+	RefreshBudget = time.Minute
+	// CatchUpInterval is how long an index that stopped at the page cap is
+	// used before the next refresh reads further.
+	CatchUpInterval = 15 * time.Minute
+)
+
+var sharedHTTP = resty.New().SetTimeout(30 * time.Second)
+
+type Entry struct {
+	Path      string    `json:"Path"`
+	Version   string    `json:"Version"`
+	Timestamp time.Time `json:"Timestamp"`
+}
+
+type Feed struct {
+	URL  string
+	http *resty.Client
+}
+
+// Index holds the newest version of every module the feed listed between
+// Start and Since.
+type Index struct {
+	Start     time.Time         `json:"start"`
+	Since     time.Time         `json:"since"`
+	FetchedAt time.Time         `json:"fetched_at"`
+	CaughtUp  bool              `json:"caught_up"`
+	Modules   map[string]string `json:"modules"`
+}
+
+func NewFeed(url string) Feed {
+	return Feed{URL: strings.TrimSuffix(url, "/"), http: sharedHTTP}
+}
+
+func DefaultCachePath() (string, error) {
+	cacheDir, err := cache.DefaultDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, "modindex.json"), nil
+}
+
+func (f Feed) Since(ctx context.Context, since time.Time, limit int) ([]Entry, error) {
+	request := f.http.R().
+		SetContext(ctx).
+		SetQueryParam("limit", fmt.Sprint(limit))
+	if !since.IsZero() {
+		request.SetQueryParam("since", since.UTC().Format(time.RFC3339Nano))
+	}
+
+	response, err := request.Get(f.URL)
+	if err != nil {
+		return nil, fmt.Errorf("query module index %s: %w", f.URL, err)
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("query module index %s: unexpected status %s", f.URL, response.Status())
+	}
+
+	entries := []Entry{}
+	scanner := bufio.NewScanner(bytes.NewReader(response.Body()))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("decode module index entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+func LoadIndex(path string) (Index, error) {
+	index := Index{Modules: map[string]string{}}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return index, nil
+		}
+		return Index{}, err
+	}
+
+	if err := json.Unmarshal(content, &index); err != nil {
+		return Index{}, fmt.Errorf("decode module index cache %s: %w", path, err)
+	}
+	if index.Modules == nil {
+		index.Modules = map[string]string{}
+	}
+
+	return index, nil
+}
+
+func SaveIndex(path string, index Index) error {
+	content, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o644)
+}
+
+func (i *Index) Merge(entries []Entry) {
+	for _, entry := range entries {
+		current, ok := i.Modules[entry.Path]
+		if !ok || modsemver.Compare(entry.Version, current) > 0 {
+			i.Modules[entry.Path] = entry.Version
+		}
+		if entry.Timestamp.After(i.Since) {
+			i.Since = entry.Timestamp
+		}
+	}
+}
+
+// Fresh reports whether the index was refreshed recently enough to use as is.
+// An index that has not caught up with the feed is refreshed again after
+// CatchUpInterval so it keeps reading forward.
+func (i Index) Fresh(ttl time.Duration, now time.Time) bool {
+	if ttl <= 0 {
+		ttl = cache.DefaultTTL
+	}
+	if !i.CaughtUp {
+		ttl = min(ttl, CatchUpInterval)
+	}
+
+	return len(i.Modules) > 0 && !i.FetchedAt.IsZero() && now.Sub(i.FetchedAt) < ttl
+}
+
+// Refresh reads at most maxPages pages of the feed after the cached index,
+// within RefreshBudget, and saves what it read. A fresh index is returned
+// without querying the feed. When the feed fails part way the pages read so
+// far are still saved and returned along with the error.
+func Refresh(ctx context.Context, feed Feed, path string, maxPages int, ttl time.Duration) (Index, error) {
+	index, err := LoadIndex(path)
+	if err != nil {
+		return Index{}, err
+	}
+
+	now := time.Now()
+	if index.Fresh(ttl, now) {
+		return index, nil
+	}
+	if index.Since.IsZero() {
+		index.Since = now.Add(-SeedWindow)
+		index.Start = index.Since
+	}
+
+	crawlCtx, cancel := context.WithTimeout(ctx, RefreshBudget)
+	defer cancel()
+
+	var crawlErr error
+	index.CaughtUp = false
+	for page := 0; page < maxPages; page++ {
+		entries, err := feed.Since(crawlCtx, index.Since, PageSize)
+		if err != nil {
+			// Running out of budget is the same as reaching the page cap.
+			if ctx.Err() == nil && errors.Is(crawlCtx.Err(), context.DeadlineExceeded) {
+				break
+			}
+			crawlErr = err
+			break
+		}
+
+		index.Merge(entries)
+		if len(entries) < PageSize {
+			index.CaughtUp = true
+			break
+		}
+	}
+	if crawlErr == nil {
+		index.FetchedAt = now
+	}
+
+	if err := SaveIndex(path, index); err != nil {
+		return Index{}, err
+	}
+
+	return index, crawlErr
+}
@@ -0,0 +1,183 @@
+package modindex_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/louiss0/go-toolkit/internal/modindex"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeFeed serves entries after the since parameter, limit at a time, and
+// records the since value of every request.
+type fakeFeed struct {
+	entries  []modindex.Entry
+	mu       sync.Mutex
+	requests []time.Time
+}
+
+func newFakeFeed(count int, start time.Time) *fakeFeed {
+	feed := &fakeFeed{}
+	for index := range count {
+		feed.entries = append(feed.entries, modindex.Entry{
+			Path:      fmt.Sprintf("example.com/module%d", index),
+			Version:   "v1.0.0",
+			Timestamp: start.Add(time.Duration(index) * time.Second),
+		})
+	}
+
+	return feed
+}
+
+func (f *fakeFeed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	since, _ := time.Parse(time.RFC3339Nano, r.URL.Query().Get("since"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	f.mu.Lock()
+	f.requests = append(f.requests, since)
+	f.mu.Unlock()
+
+	encoder := json.NewEncoder(w)
+	written := 0
+	for _, entry := range f.entries {
+		if written == limit {
+			break
+		}
+		if entry.Timestamp.After(since) {
+			_ = encoder.Encode(entry)
+			written++
+		}
+	}
+}
+
+func (f *fakeFeed) Requests() []time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]time.Time{}, f.requests...)
+}
+
+var _ = Describe("Refresh", func() {
+	assert := assert.New(GinkgoT())
+
+	var cachePath string
+	var start time.Time
+
+	BeforeEach(func() {
+		cachePath = filepath.Join(GinkgoT().TempDir(), "modindex.json")
+		start = time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	})
+
+	serve := func(feed *fakeFeed) modindex.Feed {
+		server := httptest.NewServer(feed)
+		DeferCleanup(server.Close)
+
+		return modindex.NewFeed(server.URL)
+	}
+
+	It("seeds an empty cache from a recent point instead of the start of the feed", func() {
+		feed := newFakeFeed(0, start)
+
+		index, err := modindex.Refresh(GinkgoT().Context(), serve(feed), cachePath, modindex.DefaultMaxPages, 0)
+
+		assert.NoError(err)
+		requests := feed.Requests()
+		if assert.Len(requests, 1) {
+			assert.WithinDuration(time.Now().Add(-modindex.SeedWindow), requests[0], time.Minute)
+			assert.True(index.Start.Equal(requests[0]))
+		}
+	})
+
+	It("pages until the feed returns a short page", func() {
+		feed := newFakeFeed(modindex.PageSize+5, start)
+
+		index, err := modindex.Refresh(GinkgoT().Context(), serve(feed), cachePath, modindex.DefaultMaxPages, 0)
+
+		assert.NoError(err)
+		assert.Len(feed.Requests(), 2)
+		assert.Len(index.Modules, modindex.PageSize+5)
+		assert.Equal(feed.entries[len(feed.entries)-1].Timestamp, index.Since)
+		assert.False(index.FetchedAt.IsZero())
+	})
+
+	It("uses an index that stopped at the page cap and resumes from since later", func() {
+		feed := newFakeFeed(3*modindex.PageSize+1, start)
+		modindexFeed := serve(feed)
+
+		index, err := modindex.Refresh(GinkgoT().Context(), modindexFeed, cachePath, 2, time.Hour)
+
+		assert.NoError(err)
+		assert.Len(feed.Requests(), 2)
+		assert.Len(index.Modules, 2*modindex.PageSize)
+		assert.False(index.CaughtUp)
+		assert.True(index.Fresh(time.Hour, time.Now()))
+
+		_, err = modindex.Refresh(GinkgoT().Context(), modindexFeed, cachePath, 2, time.Hour)
+
+		assert.NoError(err)
+		assert.Len(feed.Requests(), 2)
+
+		index, err = modindex.Refresh(GinkgoT().Context(), modindexFeed, cachePath, 2, time.Nanosecond)
+
+		assert.NoError(err)
+		requests := feed.Requests()
+		assert.Len(requests, 4)
+		assert.Equal(feed.entries[2*modindex.PageSize-1].Timestamp, requests[2])
+		assert.Len(index.Modules, 3*modindex.PageSize+1)
+		assert.True(index.CaughtUp)
+		assert.True(index.Fresh(time.Hour, time.Now().Add(2*modindex.CatchUpInterval)))
+	})
+
+	It("keeps the pages read before the feed failed", func() {
+		feed := newFakeFeed(2*modindex.PageSize, start)
+		pages := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pages++
+			if pages > 1 {
+				http.Error(w, "gone", http.StatusServiceUnavailable)
+				return
+			}
+			feed.ServeHTTP(w, r)
+		}))
+		DeferCleanup(server.Close)
+
+		index, err := modindex.Refresh(GinkgoT().Context(), modindex.NewFeed(server.URL), cachePath, modindex.DefaultMaxPages, 0)
+
+		assert.Error(err)
+		assert.Len(index.Modules, modindex.PageSize)
+
+		saved, err := modindex.LoadIndex(cachePath)
+		assert.NoError(err)
+		assert.Len(saved.Modules, modindex.PageSize)
+		assert.Equal(feed.entries[modindex.PageSize-1].Timestamp, saved.Since)
+		assert.True(saved.FetchedAt.IsZero())
+	})
+
+	It("reuses the cached index until the ttl expires", func() {
+		feed := newFakeFeed(3, start)
+		modindexFeed := serve(feed)
+
+		_, err := modindex.Refresh(GinkgoT().Context(), modindexFeed, cachePath, modindex.DefaultMaxPages, time.Hour)
+		assert.NoError(err)
+		index, err := modindex.Refresh(GinkgoT().Context(), modindexFeed, cachePath, modindex.DefaultMaxPages, time.Hour)
+		assert.NoError(err)
+
+		assert.Len(feed.Requests(), 1)
+		assert.Len(index.Modules, 3)
+
+		_, err = modindex.Refresh(GinkgoT().Context(), modindexFeed, cachePath, modindex.DefaultMaxPages, time.Nanosecond)
+		assert.NoError(err)
+
+		requests := feed.Requests()
+		if assert.Len(requests, 2) {
+			assert.Equal(feed.entries[2].Timestamp, requests[1])
+		}
+	})
+})
@@ -0,0 +1,94 @@
+package modproxy
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const defaultGoProxy = DefaultURL + ",direct"
+
+type Env struct {
+	Proxy   string
+	NoProxy string
+	Private string
+	Flags   string
+	GoBin   string
+	GoPath  string
+}
+
+func LoadEnv() Env {
+	fileValues := readGoEnvFile(goEnvFilePath())
+	lookup := func(key string) string {
+		if value, ok := os.LookupEnv(key); ok {
+			return strings.TrimSpace(value)
+		}
+		return strings.TrimSpace(fileValues[key])
+	}
+
+	return Env{
+		Proxy:   lookup("GOPROXY"),
+		NoProxy: lookup("GONOPROXY"),
+		Private: lookup("GOPRIVATE"),
+		Flags:   lookup("GOFLAGS"),
+		GoBin:   lookup("GOBIN"),
+		GoPath:  lookup("GOPATH"),
+	}
+}
+
+func (e Env) ProxyList() string {
+	if e.Proxy == "" {
+		return defaultGoProxy
+	}
+
+	return e.Proxy
+}
+
+func (e Env) NoProxyPatterns() string {
+	if e.NoProxy != "" {
+		return e.NoProxy
+	}
+
+	return e.Private
+}
+
+func goEnvFilePath() string {
+	if value, ok := os.LookupEnv("GOENV"); ok {
+		if value == "off" {
+			return ""
+		}
+		return value
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(configDir, "go", "env")
+}
+
+func readGoEnvFile(path string) map[string]string {
+	values := map[string]string{}
+	if path == "" {
+		return values
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return values
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !found || strings.HasPrefix(key, "#") {
+			continue
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return values
+}
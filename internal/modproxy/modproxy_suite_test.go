@@ -0,0 +1,11 @@
+package modproxy_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func TestModproxy(t *testing.T) {
+	RunSpecs(t, "Modproxy Suite")
+}
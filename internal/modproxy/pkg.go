@@ -0,0 +1,424 @@
+package modproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/louiss0/go-toolkit/internal/cache"
+	"github.com/samber/lo"
+	"golang.org/x/mod/module"
+)
+
+const DefaultURL = "https://proxy.golang.org"
+
+var (
+	ErrModuleNotFound = errors.New("module not found")
+	ErrProxyOff       = errors.New("module lookups are disabled by GOPROXY=off")
+	ErrOffline        = errors.New("not available offline")
+)
+
+var sharedHTTP = resty.New().SetTimeout(30 * time.Second)
+
+var (
+	majorVersionSuffix = regexp.MustCompile(`/v[0-9]+$`)
+	directNotFound     = regexp.MustCompile(`not found|no matching versions|unrecognized import path|unknown revision|invalid version`)
+)
+
+type Info struct {
+	Version string `json:"Version"`
+	Time    string `json:"Time"`
+}
+
+type Proxy struct {
+	URL             string
+	Direct          bool
+	Off             bool
+	FallbackOnError bool
+}
+
+type DirectQuery func(ctx context.Context, args ...string) ([]byte, error)
+
+type Client struct {
+	Proxies []Proxy
+	NoProxy string
+	Direct  DirectQuery
+	Cache   *cache.Store
+	Offline bool
+	http    *resty.Client
+}
+
+type Verification struct {
+	Input       string
+	ModulePath  string
+	Found       bool
+	Suggestions []string
+}
+
+func NewClient(baseURL string) Client {
+	return Client{
+		Proxies: []Proxy{{URL: strings.TrimSuffix(baseURL, "/")}},
+		http:    sharedHTTP,
+	}
+}
+
+func NewClientFromEnv() Client {
+	return NewClientForEnv(LoadEnv())
+}
+
+func NewClientForEnv(env Env) Client {
+	return Client{
+		Proxies: ParseProxyList(env.ProxyList()),
+		NoProxy: env.NoProxyPatterns(),
+		Direct:  goListQuery(env),
+		http:    sharedHTTP,
+	}
+}
+
+func ParseProxyList(value string) []Proxy {
+	proxies := []Proxy{}
+	for value != "" {
+		index := strings.IndexAny(value, ",|")
+		entry, separator := value, byte(0)
+		if index >= 0 {
+			entry, separator = value[:index], value[index]
+			value = value[index+1:]
+		} else {
+			value = ""
+		}
+
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		proxies = append(proxies, Proxy{
+			URL:             strings.TrimSuffix(entry, "/"),
+			Direct:          entry == "direct",
+			Off:             entry == "off",
+			FallbackOnError: separator == '|',
+		})
+	}
+
+	return proxies
+}
+
+func (c Client) String() string {
+	return strings.Join(lo.Map(c.Proxies, func(proxy Proxy, _ int) string {
+		return proxy.URL
+	}), ",")
+}
+
+func (c Client) Versions(ctx context.Context, modulePath string) ([]string, error) {
+	body, err := c.fetch(ctx, modulePath, "@v/list", func() ([]byte, error) {
+		output, err := c.direct(ctx, "list", "-m", "-versions", "-json", modulePath)
+		if err != nil {
+			return nil, err
+		}
+
+		var listed struct {
+			Versions []string `json:"Versions"`
+		}
+		if err := json.Unmarshal(output, &listed); err != nil {
+			return nil, fmt.Errorf("decode go list output for %s: %w", modulePath, err)
+		}
+
+		return []byte(strings.Join(listed.Versions, "\n")), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Filter(strings.Split(string(body), "\n"), func(version string, _ int) bool {
+		return strings.TrimSpace(version) != ""
+	}), nil
+}
+
+func (c Client) Latest(ctx context.Context, modulePath string) (Info, error) {
+	body, err := c.fetch(ctx, modulePath, "@latest", func() ([]byte, error) {
+		return c.direct(ctx, "list", "-m", "-json", modulePath+"@latest")
+	})
+	if err != nil {
+		return Info{}, err
+	}
+
+	return decodeInfo(modulePath, body)
+}
+
+func (c Client) VersionInfo(ctx context.Context, modulePath string, version string) (Info, error) {
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return Info{}, err
+	}
+
+	body, err := c.fetch(ctx, modulePath, "@v/"+escapedVersion+".info", func() ([]byte, error) {
+		return c.direct(ctx, "list", "-m", "-json", modulePath+"@"+version)
+	})
+	if err != nil {
+		return Info{}, err
+	}
+
+	return decodeInfo(modulePath, body)
+}
+
+func (c Client) GoMod(ctx context.Context, modulePath string, version string) ([]byte, error) {
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.fetch(ctx, modulePath, "@v/"+escapedVersion+".mod", func() ([]byte, error) {
+		output, err := c.direct(ctx, "mod", "download", "-json", modulePath+"@"+version)
+		if err != nil {
+			return nil, err
+		}
+
+		var downloaded struct {
+			GoMod string `json:"GoMod"`
+		}
+		if err := json.Unmarshal(output, &downloaded); err != nil {
+			return nil, fmt.Errorf("decode go mod download output for %s: %w", modulePath, err)
+		}
+
+		return os.ReadFile(downloaded.GoMod)
+	})
+}
+
+func (c Client) Exists(ctx context.Context, modulePath string) (bool, error) {
+	versions, err := c.Versions(ctx, modulePath)
+	if err != nil {
+		if errors.Is(err, ErrModuleNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if len(versions) > 0 {
+		return true, nil
+	}
+
+	if _, err := c.Latest(ctx, modulePath); err != nil {
+		if errors.Is(err, ErrModuleNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c Client) Verify(ctx context.Context, input string) (Verification, error) {
+	path, version, _ := strings.Cut(input, "@")
+	verification := Verification{Input: input, Suggestions: []string{}}
+
+	for _, candidate := range modulePrefixes(path) {
+		exists, err := c.Exists(ctx, candidate)
+		if err != nil {
+			return Verification{}, err
+		}
+		if !exists {
+			continue
+		}
+
+		verification.ModulePath = candidate
+		verification.Found = true
+		if version == "" || version == "latest" || candidate != path {
+			return verification, nil
+		}
+
+		if _, err := c.VersionInfo(ctx, candidate, version); err != nil {
+			if errors.Is(err, ErrModuleNotFound) {
+				verification.Found = false
+				return verification, nil
+			}
+			return Verification{}, err
+		}
+
+		return verification, nil
+	}
+
+	for _, candidate := range suggestionCandidates(path) {
+		exists, err := c.Exists(ctx, candidate)
+		if err != nil {
+			return Verification{}, err
+		}
+		if exists {
+			verification.Suggestions = append(verification.Suggestions, candidate)
+		}
+	}
+
+	return verification, nil
+}
+
+func (c Client) fetch(ctx context.Context, modulePath string, suffix string, direct func() ([]byte, error)) ([]byte, error) {
+	if c.NoProxy != "" && module.MatchPrefixPatterns(c.NoProxy, modulePath) {
+		if c.Offline {
+			return nil, fmt.Errorf("%s: %w", modulePath, ErrOffline)
+		}
+		return direct()
+	}
+
+	var lastErr error
+	for _, proxy := range c.Proxies {
+		var body []byte
+		var err error
+		switch {
+		case proxy.Off:
+			return nil, fmt.Errorf("%s: %w", modulePath, ErrProxyOff)
+		case proxy.Direct && c.Offline:
+			err = fmt.Errorf("%s: %w", modulePath, ErrOffline)
+		case proxy.Direct:
+			body, err = direct()
+		default:
+			body, err = c.get(ctx, proxy.URL, modulePath, suffix)
+		}
+		if err == nil {
+			return body, nil
+		}
+
+		lastErr = err
+		if !proxy.FallbackOnError && !errors.Is(err, ErrModuleNotFound) && !errors.Is(err, ErrOffline) {
+			return nil, err
+		}
+	}
+
+	if lastErr == nil {
+		return nil, fmt.Errorf("%s: %w", modulePath, ErrModuleNotFound)
+	}
+
+	return nil, lastErr
+}
+
+func (c Client) get(ctx context.Context, baseURL string, modulePath string, suffix string) ([]byte, error) {
+	escapedPath, err := module.EscapePath(modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module path %s: %w", modulePath, err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", baseURL, escapedPath, suffix)
+	if c.Cache != nil {
+		entry, err := c.Cache.Get(url)
+		if err == nil && (entry.Fresh || c.Offline || isImmutable(suffix)) {
+			return entry.Content, nil
+		}
+		if err != nil && !errors.Is(err, cache.ErrMiss) {
+			return nil, err
+		}
+	}
+	if c.Offline {
+		return nil, fmt.Errorf("%s: %w", modulePath, ErrOffline)
+	}
+
+	response, err := c.http.R().
+		SetContext(ctx).
+		Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("query %s for %s: %w", baseURL, modulePath, err)
+	}
+
+	switch response.StatusCode() {
+	case http.StatusOK:
+		if c.Cache != nil {
+			if err := c.Cache.Put(url, response.Body()); err != nil {
+				return nil, fmt.Errorf("cache %s: %w", url, err)
+			}
+		}
+		return response.Body(), nil
+	case http.StatusNotFound, http.StatusGone:
+		return nil, fmt.Errorf("%s: %w", modulePath, ErrModuleNotFound)
+	default:
+		return nil, fmt.Errorf("query %s for %s: unexpected status %s", baseURL, modulePath, response.Status())
+	}
+}
+
+func isImmutable(suffix string) bool {
+	return strings.HasPrefix(suffix, "@v/") && (strings.HasSuffix(suffix, ".info") || strings.HasSuffix(suffix, ".mod"))
+}
+
+func (c Client) direct(ctx context.Context, args ...string) ([]byte, error) {
+	if c.Direct == nil {
+		return nil, errors.New("direct module lookups are not configured")
+	}
+
+	return c.Direct(ctx, args...)
+}
+
+func goListQuery(env Env) DirectQuery {
+	return func(ctx context.Context, args ...string) ([]byte, error) {
+		command := exec.CommandContext(ctx, "go", args...)
+		command.Env = append(os.Environ(), "GOPROXY=direct", "GOFLAGS="+env.Flags)
+
+		output, err := command.Output()
+		if err == nil {
+			return output, nil
+		}
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			message := strings.TrimSpace(string(exitErr.Stderr))
+			if directNotFound.MatchString(message) {
+				return nil, fmt.Errorf("%s: %w", message, ErrModuleNotFound)
+			}
+			return nil, fmt.Errorf("go %s: %s", strings.Join(args, " "), message)
+		}
+
+		return nil, err
+	}
+}
+
+func decodeInfo(modulePath string, body []byte) (Info, error) {
+	var info Info
+	if err := json.Unmarshal(body, &info); err != nil {
+		return Info{}, fmt.Errorf("decode proxy info for %s: %w", modulePath, err)
+	}
+
+	return info, nil
+}
+
+func modulePrefixes(path string) []string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	prefixes := []string{}
+	for end := len(segments); end >= min(len(segments), 3); end-- {
+		prefixes = append(prefixes, strings.Join(segments[:end], "/"))
+	}
+
+	return prefixes
+}
+
+func suggestionCandidates(path string) []string {
+	base := majorVersionSuffix.ReplaceAllString(path, "")
+	index := strings.LastIndex(base, "/")
+	if index < 0 {
+		return []string{}
+	}
+
+	parent, name := base[:index], base[index+1:]
+	names := []string{
+		strings.ToLower(name),
+		strings.ReplaceAll(name, "_", "-"),
+		strings.ReplaceAll(name, "-", "_"),
+		"go-" + name,
+		name + "-go",
+		strings.TrimPrefix(name, "go-"),
+		strings.TrimSuffix(name, "-go"),
+	}
+
+	candidates := lo.Map(names, func(candidateName string, _ int) string {
+		return parent + "/" + candidateName
+	})
+	candidates = append(candidates, strings.ToLower(base))
+	if base != path {
+		candidates = append(candidates, base)
+	} else {
+		candidates = append(candidates, base+"/v2")
+	}
+
+	return lo.Without(lo.Uniq(candidates), path)
+}
@@ -0,0 +1,249 @@
+package modproxy_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/louiss0/go-toolkit/internal/cache"
+	"github.com/louiss0/go-toolkit/internal/modproxy"
+	"github.com/louiss0/go-toolkit/internal/testhelpers"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Client", func() {
+	assert := assert.New(GinkgoT())
+
+	var client modproxy.Client
+
+	BeforeEach(func() {
+		server := testhelpers.NewFakeProxy(map[string][]string{
+			"github.com/samber/lo":         {"v1.49.1", "v1.52.0"},
+			"github.com/BurntSushi/toml":   {"v1.4.0"},
+			"github.com/charmbracelet/huh": {"v0.8.0"},
+			"github.com/go-resty/resty/v2": {"v2.17.1"},
+			"github.com/spf13/cobra":       {},
+		})
+		DeferCleanup(server.Close)
+		client = modproxy.NewClient(server.URL)
+	})
+
+	It("lists versions with escaped module paths", func() {
+		versions, err := client.Versions(context.Background(), "github.com/BurntSushi/toml")
+
+		assert.NoError(err)
+		assert.Equal([]string{"v1.4.0"}, versions)
+	})
+
+	It("reports missing modules", func() {
+		_, err := client.Versions(context.Background(), "github.com/samber/missing")
+
+		assert.ErrorIs(err, modproxy.ErrModuleNotFound)
+	})
+
+	It("treats modules without any published version as missing", func() {
+		verification, err := client.Verify(context.Background(), "github.com/spf13/cobra")
+
+		assert.NoError(err)
+		assert.False(verification.Found)
+	})
+
+	It("finds the module that owns a package path", func() {
+		verification, err := client.Verify(context.Background(), "github.com/samber/lo/mutable")
+
+		assert.NoError(err)
+		assert.True(verification.Found)
+		assert.Equal("github.com/samber/lo", verification.ModulePath)
+	})
+
+	It("checks a requested version", func() {
+		verification, err := client.Verify(context.Background(), "github.com/samber/lo@v1.49.1")
+		assert.NoError(err)
+		assert.True(verification.Found)
+
+		verification, err = client.Verify(context.Background(), "github.com/samber/lo@v9.9.9")
+		assert.NoError(err)
+		assert.False(verification.Found)
+	})
+
+	It("suggests variants that exist on the proxy", func() {
+		verification, err := client.Verify(context.Background(), "github.com/go-resty/resty")
+
+		assert.NoError(err)
+		assert.False(verification.Found)
+		assert.Equal([]string{"github.com/go-resty/resty/v2"}, verification.Suggestions)
+
+		verification, err = client.Verify(context.Background(), "github.com/charmbracelet/go-huh")
+
+		assert.NoError(err)
+		assert.Equal([]string{"github.com/charmbracelet/huh"}, verification.Suggestions)
+	})
+})
+
+var _ = Describe("ParseProxyList", func() {
+	assert := assert.New(GinkgoT())
+
+	It("records how each entry falls back", func() {
+		proxies := modproxy.ParseProxyList("https://athens.corp|https://proxy.golang.org,direct")
+
+		assert.Equal([]modproxy.Proxy{
+			{URL: "https://athens.corp", FallbackOnError: true},
+			{URL: "https://proxy.golang.org"},
+			{URL: "direct", Direct: true},
+		}, proxies)
+	})
+
+	It("recognizes off", func() {
+		assert.Equal([]modproxy.Proxy{{URL: "off", Off: true}}, modproxy.ParseProxyList("off"))
+	})
+})
+
+var _ = Describe("Client proxy resolution", func() {
+	assert := assert.New(GinkgoT())
+
+	var publicProxy string
+	var directCalls [][]string
+	var direct modproxy.DirectQuery
+
+	BeforeEach(func() {
+		server := testhelpers.NewFakeProxy(map[string][]string{
+			"github.com/samber/lo": {"v1.52.0"},
+		})
+		DeferCleanup(server.Close)
+		publicProxy = server.URL
+
+		directCalls = [][]string{}
+		direct = func(_ context.Context, args ...string) ([]byte, error) {
+			directCalls = append(directCalls, args)
+			return []byte(`{"Path":"corp.example/billing","Versions":["v0.3.0"]}`), nil
+		}
+	})
+
+	newClient := func(env modproxy.Env) modproxy.Client {
+		client := modproxy.NewClientForEnv(env)
+		client.Direct = direct
+		return client
+	}
+
+	It("falls back to the next proxy when a module is missing", func() {
+		missingProxy := testhelpers.NewFakeProxy(map[string][]string{})
+		DeferCleanup(missingProxy.Close)
+
+		versions, err := newClient(modproxy.Env{Proxy: missingProxy.URL + "," + publicProxy}).
+			Versions(context.Background(), "github.com/samber/lo")
+
+		assert.NoError(err)
+		assert.Equal([]string{"v1.52.0"}, versions)
+	})
+
+	It("only falls back on other errors after a pipe", func() {
+		brokenProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		DeferCleanup(brokenProxy.Close)
+
+		_, err := newClient(modproxy.Env{Proxy: brokenProxy.URL + "," + publicProxy}).
+			Versions(context.Background(), "github.com/samber/lo")
+		assert.Error(err)
+
+		versions, err := newClient(modproxy.Env{Proxy: brokenProxy.URL + "|" + publicProxy}).
+			Versions(context.Background(), "github.com/samber/lo")
+		assert.NoError(err)
+		assert.Equal([]string{"v1.52.0"}, versions)
+	})
+
+	It("uses direct lookups for direct entries and private modules", func() {
+		versions, err := newClient(modproxy.Env{Proxy: publicProxy, Private: "corp.example"}).
+			Versions(context.Background(), "corp.example/billing")
+
+		assert.NoError(err)
+		assert.Equal([]string{"v0.3.0"}, versions)
+		assert.Equal([][]string{{"list", "-m", "-versions", "-json", "corp.example/billing"}}, directCalls)
+	})
+
+	It("refuses lookups when GOPROXY is off", func() {
+		_, err := newClient(modproxy.Env{Proxy: "off"}).Versions(context.Background(), "github.com/samber/lo")
+
+		assert.ErrorIs(err, modproxy.ErrProxyOff)
+	})
+})
+
+var _ = Describe("Client cache", func() {
+	assert := assert.New(GinkgoT())
+
+	It("serves cached responses when offline", func() {
+		server := testhelpers.NewFakeProxy(map[string][]string{
+			"github.com/samber/lo": {"v1.52.0"},
+		})
+		store := cache.NewStore(GinkgoT().TempDir(), time.Hour)
+		client := modproxy.NewClient(server.URL)
+		client.Cache = store
+
+		versions, err := client.Versions(context.Background(), "github.com/samber/lo")
+		assert.NoError(err)
+		assert.Equal([]string{"v1.52.0"}, versions)
+		server.Close()
+
+		client.Offline = true
+		versions, err = client.Versions(context.Background(), "github.com/samber/lo")
+
+		assert.NoError(err)
+		assert.Equal([]string{"v1.52.0"}, versions)
+	})
+
+	It("reports uncached lookups when offline", func() {
+		client := modproxy.NewClientForEnv(modproxy.Env{Proxy: "https://proxy.invalid,direct"})
+		client.Cache = cache.NewStore(GinkgoT().TempDir(), time.Hour)
+		client.Offline = true
+
+		_, err := client.Versions(context.Background(), "github.com/samber/lo")
+
+		assert.ErrorIs(err, modproxy.ErrOffline)
+	})
+})
+
+var _ = Describe("LoadEnv", func() {
+	assert := assert.New(GinkgoT())
+
+	setEnv := func(key string, value string, set bool) {
+		previous, hadPrevious := os.LookupEnv(key)
+		DeferCleanup(func() {
+			if hadPrevious {
+				_ = os.Setenv(key, previous)
+				return
+			}
+			_ = os.Unsetenv(key)
+		})
+		if set {
+			_ = os.Setenv(key, value)
+			return
+		}
+		_ = os.Unsetenv(key)
+	}
+
+	It("reads the go env file when the process env is unset", func() {
+		envFile := filepath.Join(GinkgoT().TempDir(), "env")
+		err := os.WriteFile(envFile, []byte("GOPROXY=https://athens.corp,direct\nGOPRIVATE=corp.example\n"), 0o644)
+		assert.NoError(err)
+
+		setEnv("GOENV", envFile, true)
+		setEnv("GOPROXY", "", false)
+		setEnv("GONOPROXY", "", false)
+		setEnv("GOPRIVATE", "", false)
+		setEnv("GOFLAGS", "-mod=mod", true)
+
+		env := modproxy.LoadEnv()
+
+		assert.Equal("https://athens.corp,direct", env.ProxyList())
+		assert.Equal("corp.example", env.NoProxyPatterns())
+		assert.Equal("-mod=mod", env.Flags)
+	})
+
+	It("defaults to the public proxy with a direct fallback", func() {
+		assert.Equal(modproxy.DefaultURL+",direct", modproxy.Env{}.ProxyList())
+	})
+})
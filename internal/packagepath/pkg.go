@@ -48,6 +48,9 @@ func ResolveModulePath(input string, site string, user string) (string, error) {
 	}
 
 	if len(parts) == 2 {
+		if strings.Contains(parts[0], ".") {
+			return strings.Join(parts, "/"), nil
+		}
 		if !validation.IsValidSite(site) {
 			return "", custom_errors.CreateInvalidInputErrorWithMessage("site must be in the form sitename.domain")
 		}
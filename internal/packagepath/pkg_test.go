@@ -62,6 +62,14 @@ var _ = Describe("Packagepath", func() {
 				"github.com/onsi/ginkgo/v2",
 				nil,
 			),
+			Entry(
+				"keeps two segment module paths on other hosts",
+				"google.golang.org/grpc",
+				"github.com",
+				"lou",
+				"google.golang.org/grpc",
+				nil,
+			),
 			Entry(
 				"adds the site when given user and package",
 				"acme/tool",
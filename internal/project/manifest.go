@@ -0,0 +1,210 @@
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/spf13/viper"
+)
+
+const ManifestFileName = "gtk-template.toml"
+
+const (
+	PromptInput   = "input"
+	PromptSelect  = "select"
+	PromptConfirm = "confirm"
+)
+
+type Manifest struct {
+	Description string           `mapstructure:"description"`
+	Prompts     []ManifestPrompt `mapstructure:"prompts"`
+	Files       []ManifestFile   `mapstructure:"files"`
+	Packages    []string         `mapstructure:"packages"`
+	Hooks       []ManifestHook   `mapstructure:"hooks"`
+}
+
+type ManifestPrompt struct {
+	Key         string   `mapstructure:"key"`
+	Title       string   `mapstructure:"title"`
+	Description string   `mapstructure:"description"`
+	Type        string   `mapstructure:"type"`
+	Default     string   `mapstructure:"default"`
+	Options     []string `mapstructure:"options"`
+	Required    bool     `mapstructure:"required"`
+}
+
+type ManifestFile struct {
+	Path string `mapstructure:"path"`
+	When string `mapstructure:"when"`
+}
+
+type ManifestHook struct {
+	Command string   `mapstructure:"command"`
+	Args    []string `mapstructure:"args"`
+	When    string   `mapstructure:"when"`
+}
+
+func LoadManifest(template string, templates map[string]string) (Manifest, error) {
+	templateTree, err := resolveTemplateTree(template, templates)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	return readManifest(templateTree)
+}
+
+func readManifest(templateTree fs.FS) (Manifest, error) {
+	content, err := fs.ReadFile(templateTree, ManifestFileName)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Manifest{}, nil
+		}
+		return Manifest{}, err
+	}
+
+	manifestFile := viper.New()
+	manifestFile.SetConfigType("toml")
+	if err := manifestFile.ReadConfig(bytes.NewReader(content)); err != nil {
+		return Manifest{}, fmt.Errorf("read %s: %w", ManifestFileName, err)
+	}
+
+	var manifest Manifest
+	if err := manifestFile.Unmarshal(&manifest); err != nil {
+		return Manifest{}, fmt.Errorf("read %s: %w", ManifestFileName, err)
+	}
+
+	if err := validateManifest(manifest); err != nil {
+		return Manifest{}, err
+	}
+
+	return manifest, nil
+}
+
+func validateManifest(manifest Manifest) error {
+	keys := make([]string, 0, len(manifest.Prompts))
+	for _, manifestPrompt := range manifest.Prompts {
+		if strings.TrimSpace(manifestPrompt.Key) == "" {
+			return fmt.Errorf("invalid %s: prompt key is required", ManifestFileName)
+		}
+		if slices.Contains(keys, manifestPrompt.Key) {
+			return fmt.Errorf("invalid %s: prompt key %s is duplicated", ManifestFileName, manifestPrompt.Key)
+		}
+		keys = append(keys, manifestPrompt.Key)
+
+		switch manifestPrompt.PromptType() {
+		case PromptInput, PromptConfirm:
+		case PromptSelect:
+			if len(manifestPrompt.Options) == 0 {
+				return fmt.Errorf("invalid %s: select prompt %s must include options", ManifestFileName, manifestPrompt.Key)
+			}
+		default:
+			return fmt.Errorf("invalid %s: prompt %s has unknown type %s", ManifestFileName, manifestPrompt.Key, manifestPrompt.Type)
+		}
+	}
+
+	conditions := make([]string, 0, len(manifest.Files)+len(manifest.Hooks))
+	for _, file := range manifest.Files {
+		if strings.TrimSpace(file.Path) == "" {
+			return fmt.Errorf("invalid %s: file path is required", ManifestFileName)
+		}
+		conditions = append(conditions, file.When)
+	}
+	for _, hook := range manifest.Hooks {
+		if strings.TrimSpace(hook.Command) == "" {
+			return fmt.Errorf("invalid %s: hook command is required", ManifestFileName)
+		}
+		conditions = append(conditions, hook.When)
+	}
+
+	for _, condition := range conditions {
+		key := strings.TrimPrefix(strings.TrimSpace(condition), "!")
+		if key != "" && !slices.Contains(keys, key) {
+			return fmt.Errorf("invalid %s: condition %s does not match a prompt key", ManifestFileName, condition)
+		}
+	}
+
+	return nil
+}
+
+func (p ManifestPrompt) PromptType() string {
+	if p.Type == "" {
+		return PromptInput
+	}
+
+	return p.Type
+}
+
+func (p ManifestPrompt) PromptTitle() string {
+	if p.Title == "" {
+		return p.Key
+	}
+
+	return p.Title
+}
+
+func (m Manifest) ActiveHooks(answers map[string]any) []ManifestHook {
+	return lo.Filter(m.Hooks, func(hook ManifestHook, _ int) bool {
+		return conditionMet(hook.When, answers)
+	})
+}
+
+func (m Manifest) includesFile(filePath string, answers map[string]any) bool {
+	if filePath == ManifestFileName {
+		return false
+	}
+
+	for _, file := range m.Files {
+		if matchesManifestPath(file.Path, filePath) && !conditionMet(file.When, answers) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesManifestPath(manifestPath string, filePath string) bool {
+	cleaned := path.Clean(strings.TrimPrefix(strings.TrimSpace(manifestPath), "./"))
+	for _, candidate := range []string{filePath, materializePath(filePath)} {
+		if candidate == cleaned || strings.HasPrefix(candidate, cleaned+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func conditionMet(condition string, answers map[string]any) bool {
+	trimmed := strings.TrimSpace(condition)
+	if trimmed == "" {
+		return true
+	}
+
+	negated := strings.HasPrefix(trimmed, "!")
+	met := isTruthy(answers[strings.TrimPrefix(trimmed, "!")])
+	if negated {
+		return !met
+	}
+
+	return met
+}
+
+func isTruthy(value any) bool {
+	switch typed := value.(type) {
+	case bool:
+		return typed
+	case string:
+		if parsed, err := strconv.ParseBool(typed); err == nil {
+			return parsed
+		}
+		return strings.TrimSpace(typed) != ""
+	default:
+		return false
+	}
+}
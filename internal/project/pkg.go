@@ -1,14 +1,24 @@
 package project
 
 import (
+	"bytes"
 	"embed"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
+	"os/exec"
+	"path"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/louiss0/go-toolkit/validation"
+	"github.com/samber/lo"
 )
 
 //go:embed all:assets/templates
@@ -23,79 +33,396 @@ const (
 	TemplateLib = "lib"
 )
 
+const templateSuffix = ".tmpl"
+
+var (
+	majorVersionSuffix  = regexp.MustCompile(`^v[0-9]+$`)
+	invalidPackageChars = regexp.MustCompile(`[^a-z0-9_]`)
+	goReleaseVersion    = regexp.MustCompile(`go([0-9]+\.[0-9]+)`)
+)
+
+const (
+	ConflictSkip      = "skip"
+	ConflictOverwrite = "overwrite"
+	ConflictPrompt    = "prompt"
+	ConflictNew       = "new"
+)
+
+const newFileSuffix = ".new"
+
 type Options struct {
-	Template       string
-	WriteGitIgnore bool
+	Template        string
+	Templates       map[string]string
+	WriteGitIgnore  bool
+	Data            TemplateData
+	OnConflict      string
+	ResolveConflict func(path string) (string, error)
+	BeforeWrite     func(path string) error
+}
+
+type PlannedFile struct {
+	Path    string
+	Content []byte
+	Exists  bool
+	Changed bool
+}
+
+type Plan struct {
+	Dirs  []string
+	Files []PlannedFile
+}
+
+type ConflictResolution struct {
+	Path   string
+	Action string
+}
+
+type LayoutResult struct {
+	Written   []string
+	Conflicts []ConflictResolution
+}
+
+type TemplateData struct {
+	ModulePath  string
+	PackageName string
+	User        string
+	Site        string
+	GoVersion   string
+	Year        int
+	Packages    []string
+	Answers     map[string]any
+}
+
+func NewTemplateData(modulePath string, site string, user string, packages []string) TemplateData {
+	return TemplateData{
+		ModulePath:  modulePath,
+		PackageName: PackageName(modulePath),
+		User:        user,
+		Site:        site,
+		GoVersion:   goVersion(),
+		Year:        time.Now().Year(),
+		Packages:    packages,
+		Answers:     map[string]any{},
+	}
+}
+
+func EnsureLayout(root string, options Options) (LayoutResult, error) {
+	plan, err := PlanLayout(root, options)
+	if err != nil {
+		return LayoutResult{}, err
+	}
+
+	policy := lo.Ternary(options.OnConflict == "", ConflictSkip, options.OnConflict)
+	if !slices.Contains(ConflictPolicies(), policy) {
+		return LayoutResult{}, fmt.Errorf("conflict policy must be one of: %v", ConflictPolicies())
+	}
+
+	for _, dir := range plan.Dirs {
+		dirPath := filepath.Join(root, filepath.FromSlash(dir))
+		if err := beforeWrite(options, dirPath); err != nil {
+			return LayoutResult{}, err
+		}
+		if err := os.MkdirAll(dirPath, 0o755); err != nil {
+			return LayoutResult{}, err
+		}
+	}
+
+	result := LayoutResult{Written: []string{}, Conflicts: []ConflictResolution{}}
+	for _, file := range plan.Files {
+		if file.Exists && !file.Changed {
+			continue
+		}
+
+		targetPath := file.Path
+		if file.Changed {
+			action, err := resolveConflictAction(policy, file.Path, options.ResolveConflict)
+			if err != nil {
+				return result, err
+			}
+
+			result.Conflicts = append(result.Conflicts, ConflictResolution{Path: file.Path, Action: action})
+			if action == ConflictSkip {
+				continue
+			}
+			if action == ConflictNew {
+				targetPath = file.Path + newFileSuffix
+			}
+		}
+
+		filePath := filepath.Join(root, filepath.FromSlash(targetPath))
+		if err := beforeWrite(options, filePath); err != nil {
+			return result, err
+		}
+		if err := os.WriteFile(filePath, file.Content, 0o644); err != nil {
+			return result, err
+		}
+		result.Written = append(result.Written, targetPath)
+	}
+
+	return result, nil
 }
 
-func EnsureLayout(root string, options Options) error {
+func PlanLayout(root string, options Options) (Plan, error) {
 	if _, err := validation.RequiredString(root, "root path"); err != nil {
-		return err
+		return Plan{}, err
 	}
 
-	template, err := resolveTemplate(options.Template)
+	templateTree, err := resolveTemplateTree(options.Template, options.Templates)
 	if err != nil {
-		return err
+		return Plan{}, err
 	}
 
-	templateTree, err := fs.Sub(templateFiles, templatePath(template))
+	manifest, err := readManifest(templateTree)
 	if err != nil {
-		return err
+		return Plan{}, err
 	}
 
-	if err := writeTemplate(root, templateTree); err != nil {
-		return err
+	plan := Plan{Dirs: []string{}, Files: []PlannedFile{}}
+	if err := planTemplate(root, templateTree, manifest, options.Data, &plan); err != nil {
+		return Plan{}, err
 	}
 
-	if !options.WriteGitIgnore {
+	if options.WriteGitIgnore {
+		file, err := planFile(root, ".gitignore", []byte(gitIgnoreTemplate))
+		if err != nil {
+			return Plan{}, err
+		}
+		plan.Files = append(plan.Files, file)
+	}
+
+	return plan, nil
+}
+
+func (p Plan) Conflicts() []string {
+	return lo.FilterMap(p.Files, func(file PlannedFile, _ int) (string, bool) {
+		return file.Path, file.Changed
+	})
+}
+
+func beforeWrite(options Options, path string) error {
+	if options.BeforeWrite == nil {
 		return nil
 	}
 
-	return os.WriteFile(filepath.Join(root, ".gitignore"), []byte(gitIgnoreTemplate), 0o644)
+	return options.BeforeWrite(path)
+}
+
+func ConflictPolicies() []string {
+	return []string{ConflictSkip, ConflictOverwrite, ConflictPrompt, ConflictNew}
+}
+
+func resolveConflictAction(policy string, filePath string, resolve func(string) (string, error)) (string, error) {
+	if policy != ConflictPrompt {
+		return policy, nil
+	}
+
+	if resolve == nil {
+		return "", fmt.Errorf("conflict policy %s requires a resolver", ConflictPrompt)
+	}
+
+	action, err := resolve(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	if !slices.Contains([]string{ConflictSkip, ConflictOverwrite, ConflictNew}, action) {
+		return "", fmt.Errorf("conflict action for %s must be one of: %v", filePath, []string{ConflictSkip, ConflictOverwrite, ConflictNew})
+	}
+
+	return action, nil
 }
 
 func TemplateValues() []string {
 	return []string{TemplateAPI, TemplateCLI, TemplateLib}
 }
 
-func resolveTemplate(template string) (string, error) {
+func TemplateNames(templates map[string]string) []string {
+	customNames := make([]string, 0, len(templates))
+	for name := range templates {
+		if !slices.Contains(TemplateValues(), name) {
+			customNames = append(customNames, name)
+		}
+	}
+	slices.Sort(customNames)
+
+	return append(TemplateValues(), customNames...)
+}
+
+func HasTemplate(template string, templates map[string]string) bool {
+	return slices.Contains(TemplateNames(templates), template)
+}
+
+func IsBuiltinTemplate(template string) bool {
+	return slices.Contains(TemplateValues(), template)
+}
+
+func PackageName(modulePath string) string {
+	segments := strings.Split(strings.Trim(modulePath, "/"), "/")
+	name := segments[len(segments)-1]
+	if len(segments) > 1 && majorVersionSuffix.MatchString(name) {
+		name = segments[len(segments)-2]
+	}
+
+	name = invalidPackageChars.ReplaceAllString(strings.ToLower(name), "")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return "app" + name
+	}
+
+	return name
+}
+
+func resolveTemplateTree(template string, templates map[string]string) (fs.FS, error) {
 	if template == "" {
-		return TemplateAPI, nil
+		template = TemplateAPI
 	}
 
-	for _, allowedTemplate := range TemplateValues() {
-		if template == allowedTemplate {
-			return template, nil
+	if IsBuiltinTemplate(template) {
+		if _, custom := templates[template]; custom {
+			return nil, fmt.Errorf("template %s conflicts with a built-in template", template)
 		}
+
+		return fs.Sub(templateFiles, templatePath(template))
+	}
+
+	dir, ok := templates[template]
+	if !ok {
+		return nil, fmt.Errorf("template must be one of: %v", TemplateNames(templates))
 	}
 
-	return "", fmt.Errorf("template must be one of: %v", TemplateValues())
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", template, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("template %s: %s is not a directory", template, dir)
+	}
+
+	return os.DirFS(dir), nil
 }
 
 func templatePath(template string) string {
 	return fmt.Sprintf("assets/templates/%s", template)
 }
 
-func writeTemplate(root string, templateTree fs.FS) error {
+func RenderArgs(args []string, data TemplateData) ([]string, error) {
+	rendered := make([]string, 0, len(args))
+	for _, arg := range args {
+		content, err := renderTemplate("hook argument", arg, data)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, string(content))
+	}
+
+	return rendered, nil
+}
+
+func planTemplate(root string, templateTree fs.FS, manifest Manifest, data TemplateData, plan *Plan) error {
 	return fs.WalkDir(templateTree, ".", func(path string, entry fs.DirEntry, walkErr error) error {
 		if walkErr != nil {
 			return walkErr
 		}
 
-		targetPath := filepath.Join(root, materializePath(path))
+		if entry.IsDir() && entry.Name() == ".git" {
+			return fs.SkipDir
+		}
+		if !manifest.includesFile(path, data.Answers) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
 		if entry.IsDir() {
-			return os.MkdirAll(targetPath, 0o755)
+			if path != "." {
+				plan.Dirs = append(plan.Dirs, path)
+			}
+			return nil
+		}
+
+		content, err := renderFile(templateTree, path, data)
+		if err != nil {
+			return err
 		}
 
-		content, err := fs.ReadFile(templateTree, path)
+		file, err := planFile(root, materializePath(path), content)
 		if err != nil {
 			return err
 		}
+		plan.Files = append(plan.Files, file)
 
-		return os.WriteFile(targetPath, content, 0o644)
+		return nil
 	})
 }
 
+func planFile(root string, filePath string, content []byte) (PlannedFile, error) {
+	file := PlannedFile{Path: filePath, Content: content}
+
+	existing, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(filePath)))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return file, nil
+		}
+		if info, statErr := os.Stat(filepath.Join(root, filepath.FromSlash(filePath))); statErr == nil && info.IsDir() {
+			file.Exists = true
+			file.Changed = true
+			return file, nil
+		}
+		return PlannedFile{}, err
+	}
+
+	file.Exists = true
+	file.Changed = !bytes.Equal(existing, content)
+
+	return file, nil
+}
+
+func renderFile(templateTree fs.FS, filePath string, data TemplateData) ([]byte, error) {
+	content, err := fs.ReadFile(templateTree, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasSuffix(filePath, templateSuffix) {
+		return content, nil
+	}
+
+	return renderTemplate(filePath, string(content), data)
+}
+
+func renderTemplate(name string, content string, data TemplateData) ([]byte, error) {
+	fileTemplate, err := template.New(path.Base(name)).
+		Option("missingkey=error").
+		Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", name, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := fileTemplate.Execute(&rendered, data); err != nil {
+		return nil, fmt.Errorf("render template %s: %w", name, err)
+	}
+
+	return rendered.Bytes(), nil
+}
+
 func materializePath(path string) string {
-	return strings.TrimSuffix(path, ".tmpl")
+	return strings.TrimSuffix(path, templateSuffix)
+}
+
+// goVersion reports the major.minor version of the go command on PATH, the
+// toolchain that go mod init writes into go.mod, rather than the one the
+// toolkit was built with.
+func goVersion() string {
+	output, err := exec.Command("go", "env", "GOVERSION").Output()
+	if err != nil {
+		output = []byte(runtime.Version())
+	}
+
+	match := goReleaseVersion.FindStringSubmatch(string(output))
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
 }
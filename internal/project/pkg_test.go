@@ -0,0 +1,243 @@
+package project_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/louiss0/go-toolkit/internal/project"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("NewTemplateData", func() {
+	assert := assert.New(GinkgoT())
+
+	It("takes the go version from the go command on PATH", func() {
+		if runtime.GOOS == "windows" {
+			Skip("uses a shell script in place of the go command")
+		}
+		binDir := GinkgoT().TempDir()
+		err := os.WriteFile(filepath.Join(binDir, "go"), []byte("#!/bin/sh\necho go1.21.5\n"), 0o755)
+		assert.NoError(err)
+		GinkgoT().Setenv("PATH", binDir)
+
+		data := project.NewTemplateData("github.com/lou/toolkit", "github.com", "lou", nil)
+
+		assert.Equal("1.21", data.GoVersion)
+	})
+})
+
+var _ = Describe("EnsureLayout", func() {
+	assert := assert.New(GinkgoT())
+
+	It("fails when the root path is missing", func() {
+		_, err := project.EnsureLayout("", project.Options{})
+
+		assert.Error(err)
+	})
+
+	It("rejects unknown templates", func() {
+		_, err := project.EnsureLayout(GinkgoT().TempDir(), project.Options{Template: "worker"})
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "template must be one of")
+	})
+
+	It("renders the module path into the api template", func() {
+		root := GinkgoT().TempDir()
+
+		_, err := project.EnsureLayout(root, project.Options{
+			Template: project.TemplateAPI,
+			Data:     project.NewTemplateData("github.com/lou/toolkit", "github.com", "lou", nil),
+		})
+
+		assert.NoError(err)
+
+		content, err := os.ReadFile(filepath.Join(root, "cmd", "main.go"))
+		assert.NoError(err)
+		assert.Contains(string(content), "\"github.com/lou/toolkit/internal/app\"")
+
+		content, err = os.ReadFile(filepath.Join(root, "internal", "app", "app.go"))
+		assert.NoError(err)
+		assert.Contains(string(content), "\"github.com/lou/toolkit/internal/services\"")
+	})
+
+	It("renders the package name into the cli template", func() {
+		root := GinkgoT().TempDir()
+
+		_, err := project.EnsureLayout(root, project.Options{
+			Template: project.TemplateCLI,
+			Data:     project.NewTemplateData("github.com/lou/go-toolkit/v2", "github.com", "lou", nil),
+		})
+
+		assert.NoError(err)
+
+		content, err := os.ReadFile(filepath.Join(root, "internal", "app", "app.go"))
+		assert.NoError(err)
+		assert.Contains(string(content), "const Name = \"gotoolkit\"")
+	})
+
+	Describe("with existing files", func() {
+		var root string
+		var mainPath string
+		var options project.Options
+
+		BeforeEach(func() {
+			root = GinkgoT().TempDir()
+			mainPath = filepath.Join(root, "cmd", "main.go")
+			options = project.Options{
+				Template:       project.TemplateAPI,
+				WriteGitIgnore: true,
+				Data:           project.NewTemplateData("github.com/lou/toolkit", "github.com", "lou", nil),
+			}
+
+			err := os.MkdirAll(filepath.Dir(mainPath), 0o755)
+			assert.NoError(err)
+			err = os.WriteFile(mainPath, []byte("package main\n"), 0o644)
+			assert.NoError(err)
+		})
+
+		It("reports files that would be clobbered", func() {
+			plan, err := project.PlanLayout(root, options)
+
+			assert.NoError(err)
+			assert.Equal([]string{"cmd/main.go"}, plan.Conflicts())
+		})
+
+		It("skips conflicting files by default", func() {
+			result, err := project.EnsureLayout(root, options)
+
+			assert.NoError(err)
+			assert.Equal([]project.ConflictResolution{{Path: "cmd/main.go", Action: project.ConflictSkip}}, result.Conflicts)
+
+			content, err := os.ReadFile(mainPath)
+			assert.NoError(err)
+			assert.Equal("package main\n", string(content))
+			assert.FileExists(filepath.Join(root, ".gitignore"))
+		})
+
+		It("overwrites conflicting files when asked", func() {
+			options.OnConflict = project.ConflictOverwrite
+
+			_, err := project.EnsureLayout(root, options)
+
+			assert.NoError(err)
+			content, err := os.ReadFile(mainPath)
+			assert.NoError(err)
+			assert.Contains(string(content), "github.com/lou/toolkit/internal/app")
+		})
+
+		It("writes conflicting files side by side", func() {
+			options.OnConflict = project.ConflictNew
+
+			result, err := project.EnsureLayout(root, options)
+
+			assert.NoError(err)
+			assert.Contains(result.Written, "cmd/main.go.new")
+			content, err := os.ReadFile(mainPath)
+			assert.NoError(err)
+			assert.Equal("package main\n", string(content))
+			assert.FileExists(mainPath + ".new")
+		})
+
+		It("asks the resolver for each conflict", func() {
+			options.OnConflict = project.ConflictPrompt
+			resolved := []string{}
+			options.ResolveConflict = func(path string) (string, error) {
+				resolved = append(resolved, path)
+				return project.ConflictOverwrite, nil
+			}
+
+			_, err := project.EnsureLayout(root, options)
+
+			assert.NoError(err)
+			assert.Equal([]string{"cmd/main.go"}, resolved)
+		})
+
+		It("leaves identical files untouched", func() {
+			_, err := project.EnsureLayout(root, project.Options{Template: project.TemplateLib, Data: options.Data})
+			assert.NoError(err)
+
+			plan, err := project.PlanLayout(root, project.Options{Template: project.TemplateLib, Data: options.Data})
+
+			assert.NoError(err)
+			assert.Empty(plan.Conflicts())
+		})
+	})
+})
+
+var _ = Describe("PackageName", func() {
+	assert := assert.New(GinkgoT())
+
+	DescribeTable("derives a package name from the module path",
+		func(modulePath string, expected string) {
+			assert.Equal(expected, project.PackageName(modulePath))
+		},
+		Entry("uses the last segment", "github.com/lou/toolkit", "toolkit"),
+		Entry("skips major version suffixes", "github.com/lou/toolkit/v2", "toolkit"),
+		Entry("drops invalid characters", "github.com/lou/go-toolkit", "gotoolkit"),
+		Entry("prefixes leading digits", "github.com/lou/9lives", "app9lives"),
+	)
+})
+
+var _ = Describe("LoadManifest", func() {
+	assert := assert.New(GinkgoT())
+
+	writeManifest := func(content string) map[string]string {
+		templateDir := GinkgoT().TempDir()
+		err := os.WriteFile(filepath.Join(templateDir, project.ManifestFileName), []byte(content), 0o644)
+		assert.NoError(err)
+
+		return map[string]string{"worker": templateDir}
+	}
+
+	It("returns an empty manifest for built-in templates", func() {
+		manifest, err := project.LoadManifest(project.TemplateAPI, nil)
+
+		assert.NoError(err)
+		assert.Empty(manifest.Prompts)
+	})
+
+	It("reads prompts, packages and hooks", func() {
+		templates := writeManifest(`packages = ["github.com/samber/lo"]
+
+[[prompts]]
+key = "queue"
+type = "select"
+options = ["sqs", "nats"]
+
+[[hooks]]
+command = "go"
+args = ["mod", "tidy"]
+when = "queue"
+`)
+
+		manifest, err := project.LoadManifest("worker", templates)
+
+		assert.NoError(err)
+		assert.Equal([]string{"github.com/samber/lo"}, manifest.Packages)
+		assert.Equal("queue", manifest.Prompts[0].Key)
+		assert.Equal([]string{"sqs", "nats"}, manifest.Prompts[0].Options)
+		assert.Len(manifest.ActiveHooks(map[string]any{"queue": "nats"}), 1)
+		assert.Empty(manifest.ActiveHooks(map[string]any{}))
+	})
+
+	It("rejects select prompts without options", func() {
+		templates := writeManifest("[[prompts]]\nkey = \"queue\"\ntype = \"select\"\n")
+
+		_, err := project.LoadManifest("worker", templates)
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "must include options")
+	})
+
+	It("rejects conditions that do not match a prompt", func() {
+		templates := writeManifest("[[files]]\npath = \"Dockerfile\"\nwhen = \"docker\"\n")
+
+		_, err := project.LoadManifest("worker", templates)
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "does not match a prompt key")
+	})
+})
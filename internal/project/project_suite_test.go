@@ -0,0 +1,11 @@
+package project_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func TestProject(t *testing.T) {
+	RunSpecs(t, "Project Suite")
+}
@@ -0,0 +1,281 @@
+package prompt
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/louiss0/go-toolkit/custom_errors"
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const AnswerEnvPrefix = "GTK_ANSWER_"
+
+var answerKeySeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// AnswerRunner answers prompts from preloaded values and only falls back to
+// Base when a prompt has no answer and neither Strict nor NoInput is set.
+type AnswerRunner struct {
+	Base    Runner
+	Answers Answers
+	Strict  bool
+	NoInput bool
+}
+
+func NewAnswerRunner(base Runner) *AnswerRunner {
+	return &AnswerRunner{Base: base, Answers: Answers{}}
+}
+
+// AnswerKey turns a prompt title or field key into the key used by answer
+// files, so "Use git init" and "use-git-init" both become "use_git_init".
+func AnswerKey(label string) string {
+	return strings.Trim(answerKeySeparators.ReplaceAllString(strings.ToLower(label), "_"), "_")
+}
+
+func LoadAnswers(path string) (Answers, error) {
+	answersFile := viper.New()
+	answersFile.SetConfigFile(path)
+	if err := answersFile.ReadInConfig(); err != nil {
+		return nil, custom_errors.CreateInvalidInputErrorWithMessage(
+			fmt.Sprintf("failed to read answers file %s: %v", path, err),
+		)
+	}
+
+	answers := Answers{}
+	for _, key := range answersFile.AllKeys() {
+		answers[AnswerKey(key)] = answersFile.Get(key)
+	}
+
+	return answers, nil
+}
+
+func EnvAnswers(environ []string) Answers {
+	answers := Answers{}
+	for _, entry := range environ {
+		name, value, found := strings.Cut(entry, "=")
+		if !found || !strings.HasPrefix(name, AnswerEnvPrefix) {
+			continue
+		}
+		answers[AnswerKey(strings.TrimPrefix(name, AnswerEnvPrefix))] = value
+	}
+
+	return answers
+}
+
+// Load reads the answers file at path, when set, and layers GTK_ANSWER_*
+// variables from environ on top of it.
+func (r *AnswerRunner) Load(path string, environ []string) error {
+	answers := Answers{}
+	if path != "" {
+		fileAnswers, err := LoadAnswers(path)
+		if err != nil {
+			return err
+		}
+		answers = fileAnswers
+	}
+
+	for key, value := range EnvAnswers(environ) {
+		answers[key] = value
+	}
+	r.Answers = answers
+
+	return nil
+}
+
+func (r *AnswerRunner) Input(cmd *cobra.Command, input Input) (string, error) {
+	field := Field{Kind: FieldInput, Title: input.Title, Validate: input.Validate}
+	value, found, err := r.answer(field)
+	if err != nil || found {
+		return cast.ToString(value), err
+	}
+	if err := r.unanswered(field); err != nil {
+		return "", err
+	}
+
+	return r.Base.Input(cmd, input)
+}
+
+func (r *AnswerRunner) Select(cmd *cobra.Command, selectInput Select) (string, error) {
+	field := Field{Kind: FieldSelect, Title: selectInput.Title, Options: selectInput.Options}
+	value, found, err := r.answer(field)
+	if err != nil || found {
+		return cast.ToString(value), err
+	}
+	if err := r.unanswered(field); err != nil {
+		return "", err
+	}
+
+	return r.Base.Select(cmd, selectInput)
+}
+
+func (r *AnswerRunner) MultiSelect(cmd *cobra.Command, multiSelect MultiSelect) ([]string, error) {
+	field := Field{Kind: FieldMultiSelect, Title: multiSelect.Title, Options: multiSelect.Options}
+	value, found, err := r.answer(field)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return value.([]string), nil
+	}
+	if err := r.unanswered(field); err != nil {
+		return nil, err
+	}
+
+	return r.Base.MultiSelect(cmd, multiSelect)
+}
+
+func (r *AnswerRunner) Confirm(cmd *cobra.Command, confirm Confirm) (bool, error) {
+	field := Field{Kind: FieldConfirm, Title: confirm.Title}
+	value, found, err := r.answer(field)
+	if err != nil {
+		return false, err
+	}
+	if found {
+		return value.(bool), nil
+	}
+	if err := r.unanswered(field); err != nil {
+		return false, err
+	}
+
+	return r.Base.Confirm(cmd, confirm)
+}
+
+func (r *AnswerRunner) Text(cmd *cobra.Command, text Text) (string, error) {
+	field := Field{Kind: FieldText, Title: text.Title, Validate: text.Validate}
+	value, found, err := r.answer(field)
+	if err != nil || found {
+		return cast.ToString(value), err
+	}
+	if err := r.unanswered(field); err != nil {
+		return "", err
+	}
+
+	return r.Base.Text(cmd, text)
+}
+
+// Form skips the TTY entirely when every visible field has an answer;
+// otherwise the answers become the defaults of the interactive form.
+func (r *AnswerRunner) Form(cmd *cobra.Command, form Form) (Answers, error) {
+	answers := Answers{}
+	for _, page := range form.Pages {
+		for _, field := range page.Fields {
+			value, found, err := r.answer(field)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				answers[field.Key] = value
+			}
+		}
+	}
+
+	merged := form.Defaults()
+	for key, value := range answers {
+		merged[key] = value
+	}
+
+	unanswered := []Field{}
+	for _, page := range form.Pages {
+		if page.Hide != nil && page.Hide(merged) {
+			continue
+		}
+		unanswered = append(unanswered, lo.Filter(page.Fields, func(field Field, _ int) bool {
+			return !lo.HasKey(answers, field.Key)
+		})...)
+	}
+
+	if len(unanswered) == 0 {
+		return form.Merge(answers)
+	}
+	if err := r.unanswered(unanswered[0]); err != nil {
+		return nil, err
+	}
+
+	return r.Base.Form(cmd, form.WithDefaults(answers))
+}
+
+func (r *AnswerRunner) answer(field Field) (any, bool, error) {
+	keys := lo.Uniq(lo.Compact([]string{AnswerKey(field.Key), AnswerKey(field.Title)}))
+	for _, key := range keys {
+		raw, found := r.Answers[key]
+		if !found {
+			continue
+		}
+
+		value, err := field.coerce(raw)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := field.check(value); err != nil {
+			return nil, false, err
+		}
+
+		return value, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func (field Field) coerce(raw any) (any, error) {
+	switch field.Kind {
+	case FieldConfirm:
+		if value, ok := raw.(bool); ok {
+			return value, nil
+		}
+		value, err := strconv.ParseBool(strings.TrimSpace(cast.ToString(raw)))
+		if err != nil {
+			return nil, field.invalid("must be true or false")
+		}
+		return value, nil
+	case FieldMultiSelect:
+		if text, ok := raw.(string); ok {
+			return lo.Compact(lo.Map(strings.Split(text, ","), func(value string, _ int) string {
+				return strings.TrimSpace(value)
+			})), nil
+		}
+		values, err := cast.ToStringSliceE(raw)
+		if err != nil {
+			return nil, field.invalid("must be a list")
+		}
+		return values, nil
+	case FieldText:
+		if values, ok := raw.([]any); ok {
+			return strings.Join(cast.ToStringSlice(values), "\n"), nil
+		}
+	}
+
+	value, err := cast.ToStringE(raw)
+	if err != nil {
+		return nil, field.invalid("must be text")
+	}
+
+	return value, nil
+}
+
+func (r *AnswerRunner) unanswered(field Field) error {
+	if r.Strict {
+		return unansweredError(field)
+	}
+	if r.NoInput {
+		return ErrNoInput
+	}
+
+	return nil
+}
+
+func unansweredError(field Field) error {
+	key := AnswerKey(lo.CoalesceOrEmpty(field.Key, field.Title))
+	return custom_errors.CreateInvalidInputErrorWithMessage(
+		fmt.Sprintf(
+			"no answer for prompt %q; set %s in the answers file or %s%s",
+			field.Title,
+			key,
+			AnswerEnvPrefix,
+			strings.ToUpper(key),
+		),
+	)
+}
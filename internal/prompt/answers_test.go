@@ -0,0 +1,200 @@
+package prompt_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/louiss0/go-toolkit/internal/prompt"
+	"github.com/louiss0/go-toolkit/internal/testhelpers"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("AnswerRunner", func() {
+	assert := assert.New(GinkgoT())
+
+	newForm := func() prompt.Form {
+		return prompt.Form{
+			Pages: []prompt.Page{
+				{
+					Title: "Project",
+					Fields: []prompt.Field{
+						{Key: "module", Kind: prompt.FieldInput, Title: "Module name"},
+						{
+							Key:     "provider",
+							Kind:    prompt.FieldSelect,
+							Title:   "Provider",
+							Options: []prompt.Option{{Label: "GitHub", Value: "github.com"}, {Label: "Custom", Value: "custom"}},
+							Default: "github.com",
+						},
+					},
+				},
+				{
+					Title:  "Custom provider",
+					Fields: []prompt.Field{{Key: "custom_site", Kind: prompt.FieldInput, Title: "Custom provider"}},
+					Hide: func(answers prompt.Answers) bool {
+						return answers.String("provider") != "custom"
+					},
+				},
+			},
+		}
+	}
+
+	It("normalizes titles into answer keys", func() {
+		assert.Equal("use_git_init", prompt.AnswerKey("Use git init"))
+		assert.Equal("use_git_init", prompt.AnswerKey("use-git-init"))
+		assert.Equal("packages_to_install_globally", prompt.AnswerKey(" Packages to install globally "))
+	})
+
+	It("loads answers from a file and lets env vars override them", func() {
+		answersPath := filepath.Join(GinkgoT().TempDir(), "answers.yaml")
+		err := os.WriteFile(answersPath, []byte("username: lou\nprovider: gitlab.com\n"), 0o644)
+		assert.NoError(err)
+
+		runner := prompt.NewAnswerRunner(testhelpers.NewPromptRunnerMock())
+		err = runner.Load(answersPath, []string{"GTK_ANSWER_PROVIDER=codeberg.org", "HOME=/root"})
+		assert.NoError(err)
+
+		assert.Equal(prompt.Answers{"username": "lou", "provider": "codeberg.org"}, runner.Answers)
+	})
+
+	It("reports unreadable answers files", func() {
+		runner := prompt.NewAnswerRunner(testhelpers.NewPromptRunnerMock())
+
+		err := runner.Load(filepath.Join(GinkgoT().TempDir(), "missing.toml"), nil)
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "failed to read answers file")
+	})
+
+	It("answers single prompts by title", func() {
+		runner := prompt.NewAnswerRunner(testhelpers.NewPromptRunnerMock())
+		runner.Answers = prompt.Answers{
+			"username":        "lou",
+			"use_git_init":    "false",
+			"package_presets": "cli, test",
+			"packages_to_add": []any{"samber/lo", "onsi/ginkgo/v2"},
+		}
+
+		userName, err := runner.Input(&cobra.Command{}, prompt.Input{Title: "Username"})
+		assert.NoError(err)
+		assert.Equal("lou", userName)
+
+		useGit, err := runner.Confirm(&cobra.Command{}, prompt.Confirm{Title: "Use git init", Default: true})
+		assert.NoError(err)
+		assert.False(useGit)
+
+		presets, err := runner.MultiSelect(&cobra.Command{}, prompt.MultiSelect{
+			Title:   "Package presets",
+			Options: []prompt.Option{{Label: "cli", Value: "cli"}, {Label: "test", Value: "test"}},
+		})
+		assert.NoError(err)
+		assert.Equal([]string{"cli", "test"}, presets)
+
+		packages, err := runner.Text(&cobra.Command{}, prompt.Text{Title: "Packages to add"})
+		assert.NoError(err)
+		assert.Equal("samber/lo\nonsi/ginkgo/v2", packages)
+	})
+
+	It("validates answers like the interactive prompt would", func() {
+		runner := prompt.NewAnswerRunner(testhelpers.NewPromptRunnerMock())
+		runner.Answers = prompt.Answers{"provider": "example.com", "username": "lou"}
+
+		_, err := runner.Select(&cobra.Command{}, prompt.Select{
+			Title:   "Provider",
+			Options: []prompt.Option{{Label: "GitHub", Value: "github.com"}},
+		})
+		assert.Error(err)
+		assert.Contains(err.Error(), `provider does not offer "example.com"`)
+
+		_, err = runner.Input(&cobra.Command{}, prompt.Input{
+			Title: "Username",
+			Validate: func(string) error {
+				return errors.New("username is taken")
+			},
+		})
+		assert.EqualError(err, "username is taken")
+	})
+
+	It("falls back to the base runner for unanswered prompts", func() {
+		runner := prompt.NewAnswerRunner(testhelpers.NewPromptRunnerMock(
+			testhelpers.PromptStep{Kind: testhelpers.PromptStepInput, Value: "typed"},
+		))
+
+		value, err := runner.Input(&cobra.Command{}, prompt.Input{Title: "Username"})
+
+		assert.NoError(err)
+		assert.Equal("typed", value)
+	})
+
+	It("fails on unanswered prompts in strict mode", func() {
+		runner := prompt.NewAnswerRunner(testhelpers.NewPromptRunnerMock())
+		runner.Strict = true
+
+		_, err := runner.Confirm(&cobra.Command{}, prompt.Confirm{Title: "Use git init"})
+
+		assert.Error(err)
+		assert.Contains(err.Error(), `no answer for prompt "Use git init"; set use_git_init in the answers file or GTK_ANSWER_USE_GIT_INIT`)
+	})
+
+	It("reports missing input instead of prompting when input is disabled", func() {
+		runner := prompt.NewAnswerRunner(testhelpers.NewPromptRunnerMock())
+		runner.NoInput = true
+		runner.Answers = prompt.Answers{"username": "lou"}
+
+		userName, err := runner.Input(&cobra.Command{}, prompt.Input{Title: "Username"})
+		assert.NoError(err)
+		assert.Equal("lou", userName)
+
+		_, err = runner.Form(&cobra.Command{}, newForm())
+		assert.ErrorIs(err, prompt.ErrNoInput)
+	})
+
+	It("completes forms without the base runner when every visible field is answered", func() {
+		runner := prompt.NewAnswerRunner(testhelpers.NewPromptRunnerMock())
+		runner.Answers = prompt.Answers{"module": "toolkit", "provider": "github.com"}
+
+		answers, err := runner.Form(&cobra.Command{}, newForm())
+
+		assert.NoError(err)
+		assert.Equal("toolkit", answers.String("module"))
+		assert.Equal("github.com", answers.String("provider"))
+	})
+
+	It("matches form fields by title as well as key", func() {
+		runner := prompt.NewAnswerRunner(testhelpers.NewPromptRunnerMock())
+		runner.Answers = prompt.Answers{"module_name": "toolkit", "provider": "custom", "custom_site": "git.example.com"}
+
+		answers, err := runner.Form(&cobra.Command{}, newForm())
+
+		assert.NoError(err)
+		assert.Equal("toolkit", answers.String("module"))
+		assert.Equal("git.example.com", answers.String("custom_site"))
+	})
+
+	It("prefills partially answered forms for the base runner", func() {
+		base := testhelpers.NewPromptRunnerMock(
+			testhelpers.PromptStep{Kind: testhelpers.PromptStepForm},
+		)
+		runner := prompt.NewAnswerRunner(base)
+		runner.Answers = prompt.Answers{"module": "toolkit"}
+
+		answers, err := runner.Form(&cobra.Command{}, newForm())
+
+		assert.NoError(err)
+		assert.Equal("toolkit", answers.String("module"))
+	})
+
+	It("names the first unanswered visible field in strict mode", func() {
+		runner := prompt.NewAnswerRunner(testhelpers.NewPromptRunnerMock())
+		runner.Strict = true
+		runner.Answers = prompt.Answers{"module": "toolkit", "provider": "custom"}
+
+		_, err := runner.Form(&cobra.Command{}, newForm())
+
+		assert.Error(err)
+		assert.Contains(err.Error(), `no answer for prompt "Custom provider"; set custom_site`)
+	})
+})
@@ -0,0 +1,308 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/louiss0/go-toolkit/custom_errors"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var ErrFormDeclined = fmt.Errorf("%w: form was not confirmed", huh.ErrUserAborted)
+
+type FieldKind string
+
+const (
+	FieldInput       FieldKind = "input"
+	FieldSelect      FieldKind = "select"
+	FieldMultiSelect FieldKind = "multi-select"
+	FieldConfirm     FieldKind = "confirm"
+	FieldText        FieldKind = "text"
+)
+
+type Field struct {
+	Key         string
+	Kind        FieldKind
+	Title       string
+	Description string
+	Placeholder string
+	Options     []Option
+	Default     string
+	Defaults    []string
+	Confirmed   bool
+	Validate    func(string) error
+}
+
+type Page struct {
+	Title       string
+	Description string
+	Fields      []Field
+	Hide        func(Answers) bool
+}
+
+type Form struct {
+	Pages   []Page
+	Summary func(Answers) string
+	Confirm string
+}
+
+type Answers map[string]any
+
+func (a Answers) String(key string) string {
+	value, _ := a[key].(string)
+	return value
+}
+
+func (a Answers) Bool(key string) bool {
+	value, _ := a[key].(bool)
+	return value
+}
+
+func (a Answers) Strings(key string) []string {
+	value, _ := a[key].([]string)
+	return value
+}
+
+func (f Form) Defaults() Answers {
+	answers := Answers{}
+	for _, page := range f.Pages {
+		for _, field := range page.Fields {
+			switch field.Kind {
+			case FieldConfirm:
+				answers[field.Key] = field.Confirmed
+			case FieldMultiSelect:
+				answers[field.Key] = append([]string{}, field.Defaults...)
+			default:
+				answers[field.Key] = field.Default
+			}
+		}
+	}
+
+	return answers
+}
+
+func (f Form) WithDefaults(answers Answers) Form {
+	form := f
+	form.Pages = lo.Map(f.Pages, func(page Page, _ int) Page {
+		page.Fields = lo.Map(page.Fields, func(field Field, _ int) Field {
+			value, found := answers[field.Key]
+			if !found {
+				return field
+			}
+			switch typed := value.(type) {
+			case bool:
+				field.Confirmed = typed
+			case []string:
+				field.Defaults = typed
+			case string:
+				field.Default = typed
+			}
+			return field
+		})
+		return page
+	})
+
+	return form
+}
+
+// Merge overlays answers on the form defaults and validates every visible field.
+func (f Form) Merge(answers Answers) (Answers, error) {
+	merged := f.Defaults()
+	for key, value := range answers {
+		merged[key] = value
+	}
+
+	for _, page := range f.Pages {
+		if page.Hide != nil && page.Hide(merged) {
+			continue
+		}
+		for _, field := range page.Fields {
+			if err := field.check(merged[field.Key]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+func (field Field) check(value any) error {
+	switch field.Kind {
+	case FieldConfirm:
+		if _, ok := value.(bool); !ok {
+			return field.invalid("must be true or false")
+		}
+		return nil
+	case FieldMultiSelect:
+		values, ok := value.([]string)
+		if !ok {
+			return field.invalid("must be a list")
+		}
+		for _, selected := range values {
+			if !field.hasOption(selected) {
+				return field.invalid("does not offer %q", selected)
+			}
+		}
+		return nil
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return field.invalid("must be text")
+	}
+	if field.Kind == FieldSelect && !field.hasOption(text) {
+		return field.invalid("does not offer %q", text)
+	}
+	if field.Validate != nil {
+		return field.Validate(text)
+	}
+
+	return nil
+}
+
+func (field Field) invalid(format string, args ...any) error {
+	return custom_errors.CreateInvalidInputErrorWithMessage(
+		strings.ToLower(field.Title) + " " + fmt.Sprintf(format, args...),
+	)
+}
+
+func (field Field) hasOption(value string) bool {
+	return lo.ContainsBy(field.Options, func(option Option) bool {
+		return option.Value == value
+	})
+}
+
+func (r HuhRunner) Form(cmd *cobra.Command, form Form) (Answers, error) {
+	if err := r.ready(cmd); err != nil {
+		return nil, err
+	}
+
+	state := newFormState(form)
+	groups := lo.Map(form.Pages, func(page Page, _ int) *huh.Group {
+		group := huh.NewGroup(lo.Map(page.Fields, func(field Field, _ int) huh.Field {
+			return state.field(field)
+		})...).Title(page.Title)
+		if page.Description != "" {
+			group.Description(page.Description)
+		}
+		if page.Hide != nil {
+			group.WithHideFunc(func() bool {
+				return page.Hide(state.answers())
+			})
+		}
+		return group
+	})
+
+	confirmed := true
+	if form.Summary != nil || form.Confirm != "" {
+		fields := []huh.Field{}
+		if form.Summary != nil {
+			fields = append(fields, huh.NewNote().Title("Summary").DescriptionFunc(func() string {
+				return form.Summary(state.answers())
+			}, state))
+		}
+		fields = append(fields, huh.NewConfirm().Title(lo.CoalesceOrEmpty(form.Confirm, "Continue?")).Value(&confirmed))
+		groups = append(groups, huh.NewGroup(fields...))
+	}
+
+	if err := huh.NewForm(groups...).
+		WithInput(cmd.InOrStdin()).
+		WithOutput(cmd.ErrOrStderr()).
+		Run(); err != nil {
+		return nil, err
+	}
+	if !confirmed {
+		return nil, ErrFormDeclined
+	}
+
+	return form.Merge(state.answers())
+}
+
+type formState struct {
+	Strings map[string]*string
+	Lists   map[string]*[]string
+	Bools   map[string]*bool
+}
+
+func newFormState(form Form) *formState {
+	state := &formState{
+		Strings: map[string]*string{},
+		Lists:   map[string]*[]string{},
+		Bools:   map[string]*bool{},
+	}
+	for key, value := range form.Defaults() {
+		switch typed := value.(type) {
+		case bool:
+			state.Bools[key] = &typed
+		case []string:
+			state.Lists[key] = &typed
+		case string:
+			state.Strings[key] = &typed
+		}
+	}
+
+	return state
+}
+
+func (s *formState) answers() Answers {
+	answers := Answers{}
+	for key, value := range s.Strings {
+		answers[key] = *value
+	}
+	for key, value := range s.Lists {
+		answers[key] = *value
+	}
+	for key, value := range s.Bools {
+		answers[key] = *value
+	}
+
+	return answers
+}
+
+func (s *formState) field(field Field) huh.Field {
+	options := lo.Map(field.Options, func(option Option, _ int) huh.Option[string] {
+		return huh.NewOption(option.Label, option.Value)
+	})
+
+	switch field.Kind {
+	case FieldSelect:
+		return huh.NewSelect[string]().
+			Title(field.Title).
+			Description(field.Description).
+			Options(options...).
+			Value(s.Strings[field.Key])
+	case FieldMultiSelect:
+		return huh.NewMultiSelect[string]().
+			Title(field.Title).
+			Description(field.Description).
+			Options(options...).
+			Value(s.Lists[field.Key])
+	case FieldConfirm:
+		return huh.NewConfirm().
+			Title(field.Title).
+			Description(field.Description).
+			Value(s.Bools[field.Key])
+	case FieldText:
+		text := huh.NewText().
+			Title(field.Title).
+			Description(field.Description).
+			Placeholder(field.Placeholder).
+			Value(s.Strings[field.Key])
+		if field.Validate != nil {
+			text.Validate(field.Validate)
+		}
+		return text
+	default:
+		input := huh.NewInput().
+			Title(field.Title).
+			Description(field.Description).
+			Placeholder(field.Placeholder).
+			Value(s.Strings[field.Key])
+		if field.Validate != nil {
+			input.Validate(field.Validate)
+		}
+		return input
+	}
+}
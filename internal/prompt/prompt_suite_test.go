@@ -0,0 +1,11 @@
+package prompt_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func TestPrompt(t *testing.T) {
+	RunSpecs(t, "Prompt Suite")
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/x/term"
 	"github.com/louiss0/g-tools/mode"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
@@ -11,6 +12,8 @@ import (
 
 var ErrPromptsDisabled = errors.New("prompts disabled outside production mode")
 
+var ErrNoInput = errors.New("prompts need an interactive terminal")
+
 type Input struct {
 	Title       string
 	Description string
@@ -28,9 +31,31 @@ type Select struct {
 	Options []Option
 }
 
+type MultiSelect struct {
+	Title   string
+	Options []Option
+}
+
+type Confirm struct {
+	Title       string
+	Description string
+	Default     bool
+}
+
+type Text struct {
+	Title       string
+	Description string
+	Placeholder string
+	Validate    func(string) error
+}
+
 type Runner interface {
 	Input(cmd *cobra.Command, input Input) (string, error)
 	Select(cmd *cobra.Command, selectInput Select) (string, error)
+	MultiSelect(cmd *cobra.Command, multiSelect MultiSelect) ([]string, error)
+	Confirm(cmd *cobra.Command, confirm Confirm) (bool, error)
+	Text(cmd *cobra.Command, text Text) (string, error)
+	Form(cmd *cobra.Command, form Form) (Answers, error)
 }
 
 type HuhRunner struct {
@@ -42,8 +67,8 @@ func NewRunner(modeOperator mode.ModeOperator) Runner {
 }
 
 func (r HuhRunner) Input(cmd *cobra.Command, input Input) (string, error) {
-	if !r.mode.IsProductionMode() {
-		return "", ErrPromptsDisabled
+	if err := r.ready(cmd); err != nil {
+		return "", err
 	}
 
 	value := ""
@@ -66,8 +91,8 @@ func (r HuhRunner) Input(cmd *cobra.Command, input Input) (string, error) {
 }
 
 func (r HuhRunner) Select(cmd *cobra.Command, selectInput Select) (string, error) {
-	if !r.mode.IsProductionMode() {
-		return "", ErrPromptsDisabled
+	if err := r.ready(cmd); err != nil {
+		return "", err
 	}
 
 	value := ""
@@ -86,6 +111,85 @@ func (r HuhRunner) Select(cmd *cobra.Command, selectInput Select) (string, error
 	return value, nil
 }
 
+func (r HuhRunner) MultiSelect(cmd *cobra.Command, multiSelect MultiSelect) ([]string, error) {
+	if err := r.ready(cmd); err != nil {
+		return nil, err
+	}
+
+	values := []string{}
+	field := huh.NewMultiSelect[string]().Title(multiSelect.Title).Value(&values)
+	options := lo.Map(multiSelect.Options, func(option Option, _ int) huh.Option[string] {
+		return huh.NewOption(option.Label, option.Value)
+	})
+	if len(options) > 0 {
+		field.Options(options...)
+	}
+
+	if err := runField(cmd, field); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func (r HuhRunner) Confirm(cmd *cobra.Command, confirm Confirm) (bool, error) {
+	if err := r.ready(cmd); err != nil {
+		return false, err
+	}
+
+	value := confirm.Default
+	field := huh.NewConfirm().Title(confirm.Title).Value(&value)
+	if confirm.Description != "" {
+		field.Description(confirm.Description)
+	}
+
+	if err := runField(cmd, field); err != nil {
+		return false, err
+	}
+
+	return value, nil
+}
+
+func (r HuhRunner) Text(cmd *cobra.Command, text Text) (string, error) {
+	if err := r.ready(cmd); err != nil {
+		return "", err
+	}
+
+	value := ""
+	field := huh.NewText().Title(text.Title).Value(&value)
+	if text.Description != "" {
+		field.Description(text.Description)
+	}
+	if text.Placeholder != "" {
+		field.Placeholder(text.Placeholder)
+	}
+	if text.Validate != nil {
+		field.Validate(text.Validate)
+	}
+
+	if err := runField(cmd, field); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+func (r HuhRunner) ready(cmd *cobra.Command) error {
+	if !r.mode.IsProductionMode() {
+		return ErrPromptsDisabled
+	}
+	if !isTerminal(cmd.InOrStdin()) || !isTerminal(cmd.OutOrStdout()) {
+		return ErrNoInput
+	}
+
+	return nil
+}
+
+func isTerminal(stream any) bool {
+	file, ok := stream.(interface{ Fd() uintptr })
+	return ok && term.IsTerminal(file.Fd())
+}
+
 func runField(cmd *cobra.Command, field huh.Field) error {
 	form := huh.NewForm(huh.NewGroup(field)).
 		WithInput(cmd.InOrStdin()).
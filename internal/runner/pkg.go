@@ -8,12 +8,18 @@ import (
 
 type Runner interface {
 	Run(cmd *cobra.Command, name string, args ...string) error
+	RunInDir(cmd *cobra.Command, dir string, name string, args ...string) error
 }
 
 type ExecRunner struct{}
 
-func (ExecRunner) Run(cmd *cobra.Command, name string, args ...string) error {
+func (r ExecRunner) Run(cmd *cobra.Command, name string, args ...string) error {
+	return r.RunInDir(cmd, "", name, args...)
+}
+
+func (ExecRunner) RunInDir(cmd *cobra.Command, dir string, name string, args ...string) error {
 	command := exec.Command(name, args...)
+	command.Dir = dir
 	command.Stdin = cmd.InOrStdin()
 	command.Stdout = cmd.OutOrStdout()
 	command.Stderr = cmd.ErrOrStderr()
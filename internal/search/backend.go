@@ -0,0 +1,174 @@
+package search
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/louiss0/go-toolkit/custom_errors"
+	"github.com/louiss0/go-toolkit/internal/modindex"
+)
+
+const DefaultResultLimit = 20
+
+var (
+	keywordPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+	majorSuffix    = regexp.MustCompile(`/v[0-9]+$`)
+)
+
+type Result struct {
+	Path    string
+	Version string
+}
+
+type Query struct {
+	Text     string
+	Limit    int
+	Offline  bool
+	CacheTTL time.Duration
+}
+
+type Backend interface {
+	Search(ctx context.Context, query Query) ([]Result, error)
+}
+
+// CoverageReporter is implemented by backends that only know modules
+// published after some point, so callers can say so when nothing matches.
+type CoverageReporter interface {
+	CoveredSince() time.Time
+}
+
+type MemoryBackend struct {
+	Modules map[string]string
+}
+
+type IndexBackend struct {
+	Feed      modindex.Feed
+	CachePath string
+	MaxPages  int
+}
+
+func IsKeyword(query string) bool {
+	return keywordPattern.MatchString(query)
+}
+
+func NewIndexBackend() IndexBackend {
+	cachePath, _ := modindex.DefaultCachePath()
+
+	return IndexBackend{
+		Feed:      modindex.NewFeed(modindex.DefaultURL),
+		CachePath: cachePath,
+		MaxPages:  modindex.DefaultMaxPages,
+	}
+}
+
+func (b IndexBackend) Search(ctx context.Context, query Query) ([]Result, error) {
+	if query.Offline {
+		index, err := modindex.LoadIndex(b.CachePath)
+		if err != nil {
+			return nil, err
+		}
+		if len(index.Modules) == 0 {
+			return nil, custom_errors.CreateInvalidArgumentErrorWithMessage(
+				"the module index is not cached; run without --offline to fetch it",
+			)
+		}
+
+		return MemoryBackend{Modules: index.Modules}.Search(ctx, query)
+	}
+
+	// A refresh that failed part way still returns what it read; search that
+	// rather than failing when the feed is flaky.
+	index, err := modindex.Refresh(ctx, b.Feed, b.CachePath, b.MaxPages, query.CacheTTL)
+	if err != nil && len(index.Modules) == 0 {
+		return nil, err
+	}
+
+	return MemoryBackend{Modules: index.Modules}.Search(ctx, query)
+}
+
+// CoveredSince returns the oldest release time the cached index covers, or
+// the zero time when that is unknown.
+func (b IndexBackend) CoveredSince() time.Time {
+	index, err := modindex.LoadIndex(b.CachePath)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return index.Start
+}
+
+func (b MemoryBackend) Search(_ context.Context, query Query) ([]Result, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultResultLimit
+	}
+
+	type scoredResult struct {
+		Result
+		score int
+	}
+
+	scored := []scoredResult{}
+	for path, version := range b.Modules {
+		score := matchScore(path, query.Text)
+		if score == 0 {
+			continue
+		}
+		scored = append(scored, scoredResult{Result: Result{Path: path, Version: version}, score: score})
+	}
+
+	slices.SortFunc(scored, func(a scoredResult, b scoredResult) int {
+		if a.score != b.score {
+			return b.score - a.score
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	results := []Result{}
+	for _, result := range scored[:min(limit, len(scored))] {
+		results = append(results, result.Result)
+	}
+
+	return results, nil
+}
+
+func matchScore(path string, query string) int {
+	path = strings.ToLower(path)
+	query = strings.ToLower(query)
+	name := packageName(path)
+
+	switch {
+	case name == query:
+		return 5
+	case strings.HasPrefix(name, query):
+		return 4
+	case strings.Contains(name, query):
+		return 3
+	case strings.Contains(path, query):
+		return 2
+	case isSubsequence(name, query):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func packageName(path string) string {
+	segments := strings.Split(majorSuffix.ReplaceAllString(path, ""), "/")
+
+	return segments[len(segments)-1]
+}
+
+func isSubsequence(value string, query string) bool {
+	index := 0
+	for _, character := range value {
+		if index < len(query) && rune(query[index]) == character {
+			index++
+		}
+	}
+
+	return index == len(query)
+}
@@ -0,0 +1,116 @@
+package search_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	"github.com/louiss0/go-toolkit/internal/modindex"
+	"github.com/louiss0/go-toolkit/internal/search"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("MemoryBackend", func() {
+	assert := assert.New(GinkgoT())
+
+	backend := search.MemoryBackend{Modules: map[string]string{
+		"github.com/samber/lo":         "v1.49.1",
+		"github.com/acme/slog-handler": "v0.3.0",
+		"github.com/acme/logger/v2":    "v2.1.0",
+		"github.com/acme/blog":         "v1.0.0",
+		"github.com/spf13/cobra":       "v1.10.2",
+	}}
+
+	It("ranks exact names ahead of partial and fuzzy matches", func() {
+		results, err := backend.Search(context.Background(), search.Query{Text: "log"})
+
+		assert.NoError(err)
+		assert.Equal([]search.Result{
+			{Path: "github.com/acme/logger/v2", Version: "v2.1.0"},
+			{Path: "github.com/acme/blog", Version: "v1.0.0"},
+			{Path: "github.com/acme/slog-handler", Version: "v0.3.0"},
+		}, results)
+	})
+
+	It("matches letters in order", func() {
+		results, err := backend.Search(context.Background(), search.Query{Text: "cbr"})
+
+		assert.NoError(err)
+		assert.Equal([]search.Result{{Path: "github.com/spf13/cobra", Version: "v1.10.2"}}, results)
+	})
+
+	It("honours the limit", func() {
+		results, err := backend.Search(context.Background(), search.Query{Text: "log", Limit: 1})
+
+		assert.NoError(err)
+		assert.Len(results, 1)
+	})
+})
+
+var _ = Describe("IndexBackend", func() {
+	assert := assert.New(GinkgoT())
+
+	It("caches the index feed and reads from where it left off once the ttl expires", func() {
+		requests := []string{}
+		first := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			since := r.URL.Query().Get("since")
+			requests = append(requests, since)
+			if len(requests) == 1 {
+				_, _ = fmt.Fprintf(w, `{"Path":"github.com/samber/lo","Version":"v1.49.0","Timestamp":%q}`+"\n", first.Format(time.RFC3339))
+				_, _ = fmt.Fprintf(w, `{"Path":"github.com/samber/lo","Version":"v1.49.1","Timestamp":%q}`+"\n", first.Add(time.Minute).Format(time.RFC3339))
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"Path":"github.com/acme/lotus","Version":"v0.1.0","Timestamp":%q}`+"\n", first.Add(2*time.Minute).Format(time.RFC3339))
+		}))
+		DeferCleanup(server.Close)
+
+		backend := search.IndexBackend{
+			Feed:      modindex.NewFeed(server.URL),
+			CachePath: filepath.Join(GinkgoT().TempDir(), "modindex.json"),
+			MaxPages:  modindex.DefaultMaxPages,
+		}
+
+		results, err := backend.Search(context.Background(), search.Query{Text: "lo"})
+		assert.NoError(err)
+		assert.Equal([]search.Result{{Path: "github.com/samber/lo", Version: "v1.49.1"}}, results)
+
+		results, err = backend.Search(context.Background(), search.Query{Text: "lo", CacheTTL: time.Hour})
+		assert.NoError(err)
+		assert.Len(results, 1)
+		assert.Len(requests, 1)
+
+		results, err = backend.Search(context.Background(), search.Query{Text: "lo", CacheTTL: time.Nanosecond})
+		assert.NoError(err)
+		assert.Equal([]search.Result{
+			{Path: "github.com/samber/lo", Version: "v1.49.1"},
+			{Path: "github.com/acme/lotus", Version: "v0.1.0"},
+		}, results)
+		assert.Len(requests, 2)
+		assert.Equal(first.Add(time.Minute).Format(time.RFC3339Nano), requests[1])
+	})
+
+	It("searches the cached index when the feed fails", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		DeferCleanup(server.Close)
+
+		cachePath := filepath.Join(GinkgoT().TempDir(), "modindex.json")
+		err := modindex.SaveIndex(cachePath, modindex.Index{
+			Since:   time.Now().Add(-time.Hour),
+			Modules: map[string]string{"github.com/samber/lo": "v1.49.1"},
+		})
+		assert.NoError(err)
+
+		backend := search.IndexBackend{Feed: modindex.NewFeed(server.URL), CachePath: cachePath, MaxPages: modindex.DefaultMaxPages}
+		results, err := backend.Search(context.Background(), search.Query{Text: "lo"})
+
+		assert.NoError(err)
+		assert.Equal([]search.Result{{Path: "github.com/samber/lo", Version: "v1.49.1"}}, results)
+	})
+})
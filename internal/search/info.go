@@ -0,0 +1,100 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/louiss0/go-toolkit/internal/modproxy"
+	"github.com/samber/lo"
+	"golang.org/x/mod/modfile"
+	modsemver "golang.org/x/mod/semver"
+)
+
+type Requirement struct {
+	Path     string
+	Version  string
+	Indirect bool
+}
+
+type Retraction struct {
+	Low       string
+	High      string
+	Rationale string
+}
+
+type ModuleInfo struct {
+	Path        string
+	Version     string
+	Time        string
+	GoVersion   string
+	Requires    []Requirement
+	Retractions []Retraction
+	Retracted   bool
+	Deprecated  string
+}
+
+func FetchModuleInfo(ctx context.Context, client modproxy.Client, modulePath string, version string) (ModuleInfo, error) {
+	latest, err := client.Latest(ctx, modulePath)
+	if err != nil {
+		return ModuleInfo{}, moduleLookupError(client, modulePath, err)
+	}
+
+	info := latest
+	if version != "" && version != "latest" {
+		info, err = client.VersionInfo(ctx, modulePath, version)
+		if err != nil {
+			return ModuleInfo{}, moduleLookupError(client, modulePath+"@"+version, err)
+		}
+	}
+
+	file, err := fetchModFile(ctx, client, modulePath, info.Version)
+	if err != nil {
+		return ModuleInfo{}, err
+	}
+
+	latestFile := file
+	if latest.Version != info.Version {
+		latestFile, err = fetchModFile(ctx, client, modulePath, latest.Version)
+		if err != nil {
+			return ModuleInfo{}, err
+		}
+	}
+
+	moduleInfo := ModuleInfo{
+		Path:    modulePath,
+		Version: info.Version,
+		Time:    info.Time,
+		Requires: lo.Map(file.Require, func(require *modfile.Require, _ int) Requirement {
+			return Requirement{Path: require.Mod.Path, Version: require.Mod.Version, Indirect: require.Indirect}
+		}),
+		Retractions: lo.Map(latestFile.Retract, func(retract *modfile.Retract, _ int) Retraction {
+			return Retraction{Low: retract.Low, High: retract.High, Rationale: retract.Rationale}
+		}),
+	}
+	if file.Go != nil {
+		moduleInfo.GoVersion = file.Go.Version
+	}
+	if latestFile.Module != nil {
+		moduleInfo.Deprecated = latestFile.Module.Deprecated
+	}
+	moduleInfo.Retracted = lo.ContainsBy(moduleInfo.Retractions, func(retraction Retraction) bool {
+		return modsemver.Compare(retraction.Low, moduleInfo.Version) <= 0 &&
+			modsemver.Compare(moduleInfo.Version, retraction.High) <= 0
+	})
+
+	return moduleInfo, nil
+}
+
+func fetchModFile(ctx context.Context, client modproxy.Client, modulePath string, version string) (*modfile.File, error) {
+	content, err := client.GoMod(ctx, modulePath, version)
+	if err != nil {
+		return nil, moduleLookupError(client, modulePath+"@"+version, err)
+	}
+
+	file, err := modfile.ParseLax(modulePath+"@"+version+"/go.mod", content, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parse go.mod for %s@%s: %w", modulePath, version, err)
+	}
+
+	return file, nil
+}
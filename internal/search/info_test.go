@@ -0,0 +1,72 @@
+package search_test
+
+import (
+	"context"
+
+	"github.com/louiss0/go-toolkit/internal/modproxy"
+	"github.com/louiss0/go-toolkit/internal/search"
+	"github.com/louiss0/go-toolkit/internal/testhelpers"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("FetchModuleInfo", func() {
+	assert := assert.New(GinkgoT())
+
+	var client modproxy.Client
+
+	BeforeEach(func() {
+		server := testhelpers.NewFakeProxyWithMods(map[string][]string{
+			"github.com/acme/tool": {"v1.0.0", "v1.1.0"},
+		}, map[string]string{
+			"github.com/acme/tool@v1.0.0": "module github.com/acme/tool\n\ngo 1.21\n\nrequire github.com/samber/lo v1.40.0\n",
+			"github.com/acme/tool@v1.1.0": `// Deprecated: use github.com/acme/tool/v2 instead.
+module github.com/acme/tool
+
+go 1.22
+
+require (
+	github.com/samber/lo v1.49.1
+	golang.org/x/mod v0.30.0 // indirect
+)
+
+// Published with a broken build.
+retract v1.0.0
+`,
+		})
+		DeferCleanup(server.Close)
+		client = modproxy.NewClient(server.URL)
+	})
+
+	It("describes the latest version by default", func() {
+		info, err := search.FetchModuleInfo(context.Background(), client, "github.com/acme/tool", "")
+
+		assert.NoError(err)
+		assert.Equal("v1.1.0", info.Version)
+		assert.Equal("2025-01-01T00:00:00Z", info.Time)
+		assert.Equal("1.22", info.GoVersion)
+		assert.Equal([]search.Requirement{
+			{Path: "github.com/samber/lo", Version: "v1.49.1"},
+			{Path: "golang.org/x/mod", Version: "v0.30.0", Indirect: true},
+		}, info.Requires)
+		assert.Equal("use github.com/acme/tool/v2 instead.", info.Deprecated)
+		assert.False(info.Retracted)
+	})
+
+	It("reads retractions from the latest go.mod for older versions", func() {
+		info, err := search.FetchModuleInfo(context.Background(), client, "github.com/acme/tool", "v1.0.0")
+
+		assert.NoError(err)
+		assert.Equal("1.21", info.GoVersion)
+		assert.Equal([]search.Requirement{{Path: "github.com/samber/lo", Version: "v1.40.0"}}, info.Requires)
+		assert.Equal([]search.Retraction{{Low: "v1.0.0", High: "v1.0.0", Rationale: "Published with a broken build."}}, info.Retractions)
+		assert.True(info.Retracted)
+	})
+
+	It("reports unknown versions", func() {
+		_, err := search.FetchModuleInfo(context.Background(), client, "github.com/acme/tool", "v9.0.0")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "module github.com/acme/tool@v9.0.0 was not found")
+	})
+})
@@ -2,41 +2,122 @@ package search
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path"
 	"strings"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/louiss0/go-toolkit/custom_errors"
+	"github.com/louiss0/go-toolkit/internal/cache"
+	"github.com/louiss0/go-toolkit/internal/modproxy"
 	"github.com/samber/lo"
 )
 
-const (
-	defaultProvider = "github.com"
-	proxyBaseURL    = "https://proxy.golang.org"
-)
+type ClientOptions struct {
+	Proxy   string
+	Cache   *cache.Store
+	Offline bool
+}
 
-func ResolveModulePath(query string) string {
-	if strings.HasPrefix(query, defaultProvider+"/") {
-		return query
+func NewClient(options ClientOptions) modproxy.Client {
+	env := modproxy.LoadEnv()
+	if options.Proxy != "" {
+		env.Proxy = options.Proxy
 	}
 
-	return defaultProvider + "/" + query
+	client := modproxy.NewClientForEnv(env)
+	client.Cache = options.Cache
+	client.Offline = options.Offline
+
+	return client
 }
 
-func FetchModuleVersions(ctx context.Context, modulePath string) ([]string, error) {
-	endpoint := fmt.Sprintf("%s/%s/@v/list", proxyBaseURL, modulePath)
-	client := resty.New()
+func FetchModuleVersions(ctx context.Context, client modproxy.Client, modulePath string, filter Filter) ([]string, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
 
-	res, err := client.R().
-		SetContext(ctx).
-		Get(endpoint)
+	versions, err := fetchVersions(ctx, client, modulePath)
 	if err != nil {
-		return nil, custom_errors.CreateInvalidArgumentErrorWithMessage(
-			fmt.Sprintf("failed to fetch module versions for %s: %v", modulePath, err),
-		)
+		return nil, moduleLookupError(client, modulePath, err)
+	}
+
+	selected, err := SelectVersions(versions, filter)
+	if err != nil {
+		return nil, err
 	}
 
-	return lo.Map(strings.Split(res.String(), "\n"), func(version string, _ int) string {
+	return lo.Map(selected, func(version string, _ int) string {
 		return fmt.Sprintf("%s@%s", modulePath, version)
 	}), nil
 }
+
+// ResolvePackageVersion picks the newest version matching query for the
+// module that contains packagePath, walking up the path until the proxy
+// knows the module. Like go install, latest prefers stable releases.
+func ResolvePackageVersion(ctx context.Context, client modproxy.Client, packagePath string, query string) (string, error) {
+	filter, err := ApplyVersionQuery(Filter{Latest: true}, query)
+	if err != nil {
+		return "", err
+	}
+	filter.Stable = query == "" || query == "latest"
+
+	modulePath := packagePath
+	for {
+		versions, err := fetchVersions(ctx, client, modulePath)
+		if err == nil {
+			selected, err := SelectVersions(versions, filter)
+			if err != nil {
+				return "", err
+			}
+			if len(selected) == 0 && filter.Stable {
+				filter.Stable = false
+				selected, err = SelectVersions(versions, filter)
+				if err != nil {
+					return "", err
+				}
+			}
+			if len(selected) == 0 {
+				return "", custom_errors.CreateInvalidArgumentErrorWithMessage(
+					fmt.Sprintf("no version of %s matches %q", modulePath, query),
+				)
+			}
+			return selected[0], nil
+		}
+		if !errors.Is(err, modproxy.ErrModuleNotFound) || !strings.Contains(modulePath, "/") {
+			return "", moduleLookupError(client, packagePath, err)
+		}
+		modulePath = path.Dir(modulePath)
+	}
+}
+
+func fetchVersions(ctx context.Context, client modproxy.Client, modulePath string) ([]string, error) {
+	versions, err := client.Versions(ctx, modulePath)
+	if err != nil || len(versions) > 0 {
+		return versions, err
+	}
+
+	latest, err := client.Latest(ctx, modulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{latest.Version}, nil
+}
+
+func moduleLookupError(client modproxy.Client, target string, err error) error {
+	switch {
+	case errors.Is(err, modproxy.ErrModuleNotFound):
+		return custom_errors.CreateInvalidArgumentErrorWithMessage(
+			fmt.Sprintf("module %s was not found on %s", target, client),
+		)
+	case errors.Is(err, modproxy.ErrOffline):
+		return custom_errors.CreateInvalidArgumentErrorWithMessage(
+			fmt.Sprintf("module %s is not cached; run without --offline to fetch it", target),
+		)
+	default:
+		return custom_errors.CreateInvalidArgumentErrorWithMessage(
+			fmt.Sprintf("failed to fetch module metadata for %s: %v", target, err),
+		)
+	}
+}
@@ -0,0 +1,11 @@
+package search_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func TestSearch(t *testing.T) {
+	RunSpecs(t, "Search Suite")
+}
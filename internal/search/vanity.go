@@ -0,0 +1,78 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/net/html"
+)
+
+type MetaFetcher func(ctx context.Context, url string) ([]byte, error)
+
+var metaHTTP = resty.New().SetTimeout(30 * time.Second)
+
+func FetchGoImportMeta(ctx context.Context, url string) ([]byte, error) {
+	response, err := metaHTTP.R().SetContext(ctx).Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("query %s: unexpected status %s", url, response.Status())
+	}
+
+	return response.Body(), nil
+}
+
+func ResolveImportPath(ctx context.Context, importPath string, fetch MetaFetcher) (string, error) {
+	body, err := fetch(ctx, "https://"+importPath+"?go-get=1")
+	if err != nil {
+		return "", err
+	}
+
+	for _, prefix := range goImportPrefixes(body) {
+		if importPath == prefix || strings.HasPrefix(importPath, prefix+"/") {
+			return prefix, nil
+		}
+	}
+
+	return "", fmt.Errorf("no go-import meta tag found for %s", importPath)
+}
+
+func goImportPrefixes(body []byte) []string {
+	prefixes := []string{}
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return prefixes
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.Data == "body" {
+				return prefixes
+			}
+			if token.Data != "meta" {
+				continue
+			}
+
+			var name, content string
+			for _, attribute := range token.Attr {
+				switch attribute.Key {
+				case "name":
+					name = attribute.Val
+				case "content":
+					content = attribute.Val
+				}
+			}
+
+			fields := strings.Fields(content)
+			if name == "go-import" && len(fields) == 3 {
+				prefixes = append(prefixes, fields[0])
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package search_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/louiss0/go-toolkit/internal/search"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("ResolveImportPath", func() {
+	assert := assert.New(GinkgoT())
+
+	page := func(body string) search.MetaFetcher {
+		return func(_ context.Context, url string) ([]byte, error) {
+			assert.Equal("https://go.uber.org/zap/zapcore?go-get=1", url)
+			return []byte(body), nil
+		}
+	}
+
+	It("returns the module root from the go-import meta tag", func() {
+		root, err := search.ResolveImportPath(context.Background(), "go.uber.org/zap/zapcore", page(`<!DOCTYPE html>
+<html><head>
+<meta name="go-source" content="go.uber.org/zap https://github.com/uber-go/zap _ _">
+<meta name="go-import" content="go.uber.org/zap git https://github.com/uber-go/zap">
+</head><body>Nothing to see here.</body></html>`))
+
+		assert.NoError(err)
+		assert.Equal("go.uber.org/zap", root)
+	})
+
+	It("ignores meta tags for other prefixes", func() {
+		_, err := search.ResolveImportPath(context.Background(), "go.uber.org/zap/zapcore", page(
+			`<meta name="go-import" content="go.uber.org/atomic git https://github.com/uber-go/atomic">`,
+		))
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "no go-import meta tag found")
+	})
+
+	It("returns fetch errors", func() {
+		_, err := search.ResolveImportPath(context.Background(), "go.uber.org/zap", func(context.Context, string) ([]byte, error) {
+			return nil, errors.New("offline")
+		})
+
+		assert.EqualError(err, "offline")
+	})
+})
@@ -0,0 +1,125 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/louiss0/go-toolkit/custom_errors"
+	"github.com/samber/lo"
+	modsemver "golang.org/x/mod/semver"
+)
+
+var (
+	majorPattern      = regexp.MustCompile(`^v?[0-9]+$`)
+	majorQueryPattern = regexp.MustCompile(`^v[0-9]+$`)
+)
+
+type Filter struct {
+	Latest     bool
+	Stable     bool
+	Major      string
+	Constraint string
+	Limit      int
+}
+
+func (f Filter) Validate() error {
+	if f.Major != "" && !majorPattern.MatchString(f.Major) {
+		return custom_errors.CreateInvalidArgumentErrorWithMessage("major must be in the form vN")
+	}
+
+	if f.Constraint != "" {
+		if _, err := semver.NewConstraint(f.Constraint); err != nil {
+			return custom_errors.CreateInvalidArgumentErrorWithMessage(
+				fmt.Sprintf("invalid version constraint %q: %v", f.Constraint, err),
+			)
+		}
+	}
+
+	if f.Limit < 0 {
+		return custom_errors.CreateInvalidArgumentErrorWithMessage("limit must not be negative")
+	}
+
+	return nil
+}
+
+func ApplyVersionQuery(filter Filter, query string) (Filter, error) {
+	var constraint string
+	switch {
+	case query == "":
+		return filter, nil
+	case query == "latest":
+		filter.Latest = true
+		return filter, nil
+	case majorQueryPattern.MatchString(query):
+		filter.Major = query
+		return filter, nil
+	case modsemver.IsValid(query) && modsemver.Canonical(query) == query:
+		constraint = "=" + query
+	case modsemver.IsValid(query):
+		constraint = "~" + query
+	default:
+		constraint = query
+	}
+
+	if _, err := semver.NewConstraint(constraint); err != nil {
+		return Filter{}, custom_errors.CreateInvalidArgumentErrorWithMessage(
+			fmt.Sprintf("invalid version query %q: use latest, vN, vN.N, a full version or a constraint", query),
+		)
+	}
+	if filter.Constraint != "" {
+		constraint = filter.Constraint + ", " + constraint
+	}
+	filter.Constraint = constraint
+
+	return filter, nil
+}
+
+func SelectVersions(versions []string, filter Filter) ([]string, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	selected := lo.Filter(lo.Uniq(versions), func(version string, _ int) bool {
+		return modsemver.IsValid(version)
+	})
+	slices.SortFunc(selected, func(a string, b string) int {
+		return modsemver.Compare(b, a)
+	})
+
+	if filter.Stable {
+		selected = lo.Filter(selected, func(version string, _ int) bool {
+			return modsemver.Prerelease(version) == ""
+		})
+	}
+
+	if filter.Major != "" {
+		major := "v" + strings.TrimPrefix(filter.Major, "v")
+		selected = lo.Filter(selected, func(version string, _ int) bool {
+			return modsemver.Major(version) == major
+		})
+	}
+
+	if filter.Constraint != "" {
+		constraint, err := semver.NewConstraint(filter.Constraint)
+		if err != nil {
+			return nil, err
+		}
+		selected = lo.Filter(selected, func(version string, _ int) bool {
+			parsed, err := semver.NewVersion(version)
+			return err == nil && constraint.Check(parsed)
+		})
+	}
+
+	if filter.Latest && len(selected) > 1 {
+		selected = selected[:1]
+	}
+
+	if filter.Limit > 0 && len(selected) > filter.Limit {
+		selected = selected[:filter.Limit]
+	}
+
+	return selected, nil
+}
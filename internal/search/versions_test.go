@@ -0,0 +1,150 @@
+package search_test
+
+import (
+	"context"
+
+	"github.com/louiss0/go-toolkit/internal/modproxy"
+	"github.com/louiss0/go-toolkit/internal/search"
+	"github.com/louiss0/go-toolkit/internal/testhelpers"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("SelectVersions", func() {
+	assert := assert.New(GinkgoT())
+
+	versions := []string{"v1.2.0", "v0.9.0", "", "v2.0.0-rc.1", "v1.10.0", "v2.1.0", "v1.2.0"}
+
+	DescribeTable("sorts and filters versions",
+		func(filter search.Filter, expected []string) {
+			selected, err := search.SelectVersions(versions, filter)
+
+			assert.NoError(err)
+			assert.Equal(expected, selected)
+		},
+		Entry("sorts newest first", search.Filter{}, []string{"v2.1.0", "v2.0.0-rc.1", "v1.10.0", "v1.2.0", "v0.9.0"}),
+		Entry("drops prereleases", search.Filter{Stable: true}, []string{"v2.1.0", "v1.10.0", "v1.2.0", "v0.9.0"}),
+		Entry("keeps one major version", search.Filter{Major: "v1"}, []string{"v1.10.0", "v1.2.0"}),
+		Entry("accepts a bare major number", search.Filter{Major: "2"}, []string{"v2.1.0", "v2.0.0-rc.1"}),
+		Entry("applies constraints", search.Filter{Constraint: ">=1.2 <2"}, []string{"v1.10.0", "v1.2.0"}),
+		Entry("resolves the latest version", search.Filter{Latest: true, Major: "v1"}, []string{"v1.10.0"}),
+		Entry("limits the result", search.Filter{Limit: 2}, []string{"v2.1.0", "v2.0.0-rc.1"}),
+	)
+
+	DescribeTable("rejects invalid filters",
+		func(filter search.Filter, message string) {
+			_, err := search.SelectVersions(versions, filter)
+
+			assert.Error(err)
+			assert.Contains(err.Error(), message)
+		},
+		Entry("major without a number", search.Filter{Major: "vx"}, "major must be in the form vN"),
+		Entry("malformed constraints", search.Filter{Constraint: ">>1"}, "invalid version constraint"),
+		Entry("negative limits", search.Filter{Limit: -1}, "limit must not be negative"),
+	)
+})
+
+var _ = Describe("FetchModuleVersions", func() {
+	assert := assert.New(GinkgoT())
+
+	var client modproxy.Client
+
+	BeforeEach(func() {
+		server := testhelpers.NewFakeProxy(map[string][]string{
+			"github.com/acme/tool":     {"v1.1.0", "v1.0.0"},
+			"github.com/acme/snapshot": {"v0.0.0-20250101000000-abcdefabcdef"},
+		})
+		DeferCleanup(server.Close)
+		client = modproxy.NewClient(server.URL)
+	})
+
+	It("prefixes sorted versions with the module path", func() {
+		versions, err := search.FetchModuleVersions(context.Background(), client, "github.com/acme/tool", search.Filter{})
+
+		assert.NoError(err)
+		assert.Equal([]string{"github.com/acme/tool@v1.1.0", "github.com/acme/tool@v1.0.0"}, versions)
+	})
+
+	It("falls back to the latest endpoint when no versions are tagged", func() {
+		versions, err := search.FetchModuleVersions(context.Background(), client, "github.com/acme/snapshot", search.Filter{})
+
+		assert.NoError(err)
+		assert.Equal([]string{"github.com/acme/snapshot@v0.0.0-20250101000000-abcdefabcdef"}, versions)
+	})
+
+	It("reports missing modules", func() {
+		_, err := search.FetchModuleVersions(context.Background(), client, "github.com/acme/missing", search.Filter{})
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "module github.com/acme/missing was not found")
+	})
+})
+
+var _ = Describe("ApplyVersionQuery", func() {
+	assert := assert.New(GinkgoT())
+
+	DescribeTable("maps version queries onto filters",
+		func(query string, expected search.Filter) {
+			filter, err := search.ApplyVersionQuery(search.Filter{}, query)
+
+			assert.NoError(err)
+			assert.Equal(expected, filter)
+		},
+		Entry("leaves the filter alone without a query", "", search.Filter{}),
+		Entry("resolves latest", "latest", search.Filter{Latest: true}),
+		Entry("selects a major version", "v2", search.Filter{Major: "v2"}),
+		Entry("matches a minor series", "v1.2", search.Filter{Constraint: "~v1.2"}),
+		Entry("matches an exact version", "v1.2.3", search.Filter{Constraint: "=v1.2.3"}),
+		Entry("passes constraints through", ">=v1.2", search.Filter{Constraint: ">=v1.2"}),
+	)
+
+	It("combines queries with an existing constraint", func() {
+		filter, err := search.ApplyVersionQuery(search.Filter{Constraint: "<2"}, "v1.2")
+
+		assert.NoError(err)
+		assert.Equal("<2, ~v1.2", filter.Constraint)
+	})
+
+	It("rejects queries it cannot interpret", func() {
+		_, err := search.ApplyVersionQuery(search.Filter{}, "main branch")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), "invalid version query")
+	})
+})
+
+var _ = Describe("ResolvePackageVersion", func() {
+	assert := assert.New(GinkgoT())
+
+	var client modproxy.Client
+
+	BeforeEach(func() {
+		server := testhelpers.NewFakeProxy(map[string][]string{
+			"golang.org/x/tools":   {"v0.20.0", "v0.21.0", "v0.22.0-rc.1"},
+			"github.com/acme/beta": {"v0.1.0-beta.1"},
+		})
+		DeferCleanup(server.Close)
+		client = modproxy.NewClient(server.URL)
+	})
+
+	It("walks up from a package to its module and prefers stable releases", func() {
+		version, err := search.ResolvePackageVersion(context.Background(), client, "golang.org/x/tools/cmd/goimports", "latest")
+
+		assert.NoError(err)
+		assert.Equal("v0.21.0", version)
+	})
+
+	It("falls back to pre-releases when nothing stable exists", func() {
+		version, err := search.ResolvePackageVersion(context.Background(), client, "github.com/acme/beta", "")
+
+		assert.NoError(err)
+		assert.Equal("v0.1.0-beta.1", version)
+	})
+
+	It("reports constraints that match nothing", func() {
+		_, err := search.ResolvePackageVersion(context.Background(), client, "golang.org/x/tools/cmd/goimports", ">=1.0.0")
+
+		assert.Error(err)
+		assert.Contains(err.Error(), `no version of golang.org/x/tools matches ">=1.0.0"`)
+	})
+})
@@ -0,0 +1,57 @@
+package testhelpers
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// BuildGoBinary compiles an empty main package whose embedded build info
+// names packagePath, so tests can install binaries that look like the real
+// thing to debug/buildinfo.
+func BuildGoBinary(outputPath string, packagePath string) error {
+	return BuildGoBinaryAt(outputPath, packagePath, "")
+}
+
+// BuildGoBinaryAt works like BuildGoBinary and, when version is set, tags the
+// module source so the go command stamps that version into the build info.
+func BuildGoBinaryAt(outputPath string, packagePath string, version string) error {
+	moduleDir, err := os.MkdirTemp("", "gtk-binary-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(moduleDir)
+
+	if err := os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte("module "+packagePath+"\n\ngo 1.24\n"), 0o644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(moduleDir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		return err
+	}
+
+	if version != "" {
+		for _, args := range [][]string{
+			{"init", "--quiet"},
+			{"add", "--all"},
+			{"-c", "user.name=gtk", "-c", "user.email=gtk@example.com", "commit", "--quiet", "--message", "build"},
+			{"tag", version},
+		} {
+			git := exec.Command("git", args...)
+			git.Dir = moduleDir
+			if output, err := git.CombinedOutput(); err != nil {
+				return fmt.Errorf("git %s: %w\n%s", strings.Join(args, " "), err, output)
+			}
+		}
+	}
+
+	build := exec.Command("go", "build", "-o", outputPath, ".")
+	build.Dir = moduleDir
+	build.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=")
+	if output, err := build.CombinedOutput(); err != nil {
+		return fmt.Errorf("go build %s: %w\n%s", packagePath, err, output)
+	}
+
+	return nil
+}
@@ -29,6 +29,11 @@ func (m *RunnerMock) Run(cmd *cobra.Command, name string, args ...string) error
 	return call.Error(0)
 }
 
+func (m *RunnerMock) RunInDir(cmd *cobra.Command, dir string, name string, args ...string) error {
+	call := m.Called(cmd, dir, name, args)
+	return call.Error(0)
+}
+
 func ExecuteCmd(cmd *cobra.Command, args ...string) (string, error) {
 	buf := new(bytes.Buffer)
 	errBuff := new(bytes.Buffer)
@@ -48,14 +53,21 @@ func ExecuteCmd(cmd *cobra.Command, args ...string) (string, error) {
 type PromptStepKind string
 
 const (
-	PromptStepInput  PromptStepKind = "input"
-	PromptStepSelect PromptStepKind = "select"
+	PromptStepInput       PromptStepKind = "input"
+	PromptStepSelect      PromptStepKind = "select"
+	PromptStepMultiSelect PromptStepKind = "multi-select"
+	PromptStepConfirm     PromptStepKind = "confirm"
+	PromptStepText        PromptStepKind = "text"
+	PromptStepForm        PromptStepKind = "form"
 )
 
 type PromptStep struct {
-	Kind  PromptStepKind
-	Value string
-	Err   error
+	Kind      PromptStepKind
+	Value     string
+	Values    []string
+	Confirmed bool
+	Answers   prompt.Answers
+	Err       error
 }
 
 type PromptRunnerMock struct {
@@ -100,6 +112,64 @@ func (m *PromptRunnerMock) Select(_ *cobra.Command, selectInput prompt.Select) (
 	return "", fmt.Errorf("unexpected selection: %s", step.Value)
 }
 
+func (m *PromptRunnerMock) MultiSelect(_ *cobra.Command, multiSelect prompt.MultiSelect) ([]string, error) {
+	step, err := m.next(PromptStepMultiSelect)
+	if err != nil {
+		return nil, err
+	}
+	if step.Err != nil {
+		return nil, step.Err
+	}
+	for _, value := range step.Values {
+		optionExists := lo.ContainsBy(multiSelect.Options, func(option prompt.Option) bool {
+			return option.Value == value
+		})
+		if !optionExists {
+			return nil, fmt.Errorf("unexpected selection: %s", value)
+		}
+	}
+	return step.Values, nil
+}
+
+func (m *PromptRunnerMock) Confirm(_ *cobra.Command, _ prompt.Confirm) (bool, error) {
+	step, err := m.next(PromptStepConfirm)
+	if err != nil {
+		return false, err
+	}
+	if step.Err != nil {
+		return false, step.Err
+	}
+	return step.Confirmed, nil
+}
+
+func (m *PromptRunnerMock) Text(_ *cobra.Command, text prompt.Text) (string, error) {
+	step, err := m.next(PromptStepText)
+	if err != nil {
+		return "", err
+	}
+	if step.Err != nil {
+		return "", step.Err
+	}
+	if text.Validate != nil {
+		if err := text.Validate(step.Value); err != nil {
+			return "", err
+		}
+	}
+	return step.Value, nil
+}
+
+// Form fills unscripted fields with their defaults and validates the result.
+func (m *PromptRunnerMock) Form(_ *cobra.Command, form prompt.Form) (prompt.Answers, error) {
+	step, err := m.next(PromptStepForm)
+	if err != nil {
+		return nil, err
+	}
+	if step.Err != nil {
+		return nil, step.Err
+	}
+	return form.Merge(step.Answers)
+}
+
 func (m *PromptRunnerMock) next(expected PromptStepKind) (PromptStep, error) {
 	if m.index >= len(m.steps) {
 		return PromptStep{}, fmt.Errorf("prompt mock: no steps remaining")
@@ -0,0 +1,80 @@
+package testhelpers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/mod/module"
+)
+
+func NewFakeProxy(modules map[string][]string) *httptest.Server {
+	return NewFakeProxyWithMods(modules, nil)
+}
+
+func NewFakeProxyWithMods(modules map[string][]string, mods map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		escapedPath, endpoint, found := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/@")
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+
+		modulePath, err := module.UnescapePath(escapedPath)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		versions, ok := modules[modulePath]
+		if !ok {
+			http.Error(w, "not found: "+modulePath, http.StatusNotFound)
+			return
+		}
+
+		switch {
+		case endpoint == "v/list":
+			listed := lo.Reject(versions, func(version string, _ int) bool {
+				return module.IsPseudoVersion(version)
+			})
+			_, _ = w.Write([]byte(strings.Join(listed, "\n")))
+		case endpoint == "latest":
+			if len(versions) == 0 {
+				http.NotFound(w, r)
+				return
+			}
+			writeProxyInfo(w, versions[len(versions)-1])
+		case strings.HasPrefix(endpoint, "v/") && strings.HasSuffix(endpoint, ".info"):
+			version, err := module.UnescapeVersion(strings.TrimSuffix(strings.TrimPrefix(endpoint, "v/"), ".info"))
+			if err != nil || !slices.Contains(versions, version) {
+				http.NotFound(w, r)
+				return
+			}
+			writeProxyInfo(w, version)
+		case strings.HasPrefix(endpoint, "v/") && strings.HasSuffix(endpoint, ".mod"):
+			version, err := module.UnescapeVersion(strings.TrimSuffix(strings.TrimPrefix(endpoint, "v/"), ".mod"))
+			if err != nil || !slices.Contains(versions, version) {
+				http.NotFound(w, r)
+				return
+			}
+			content, ok := mods[modulePath+"@"+version]
+			if !ok {
+				content = "module " + modulePath + "\n"
+			}
+			_, _ = w.Write([]byte(content))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func writeProxyInfo(w http.ResponseWriter, version string) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"Version": version,
+		"Time":    "2025-01-01T00:00:00Z",
+	})
+}